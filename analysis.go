@@ -0,0 +1,236 @@
+package lambda
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// FreeVarCounts returns how many times each free variable occurs in t.
+// For example x x y gives {x: 2, y: 1}, while λf.λx.f (f x) has no free
+// variables and gives an empty map. In a NumeralApply λx.F^n x, the
+// occurrences in F count n times, as in its expansion.
+func FreeVarCounts(t Term) map[string]int {
+	counts := make(map[string]int)
+	countFreeVars(t, nil, 1, counts)
+	return counts
+}
+
+// countFreeVars adds weight to the count of each free occurrence in t,
+// where bound holds the names of the enclosing binders.
+func countFreeVars(t Term, bound map[string]int, weight int, counts map[string]int) {
+	switch t := unwrap(t).(type) {
+	case Var:
+		if bound[t.Name] == 0 {
+			counts[t.Name] += weight
+		}
+	case Abstraction:
+		if bound == nil {
+			bound = make(map[string]int)
+		}
+		bound[t.Param]++
+		countFreeVars(t.Body, bound, weight, counts)
+		bound[t.Param]--
+	case Application:
+		countFreeVars(t.Func, bound, weight, counts)
+		countFreeVars(t.Arg, bound, weight, counts)
+	case NumeralApply:
+		// λx.f^0 x is λx.x, where f does not occur
+		if t.N > 0 {
+			countFreeVars(t.F, bound, weight*int(t.N), counts)
+		}
+	}
+}
+
+// IsClosed reports whether t has no free variables, i.e. is a combinator.
+func IsClosed(t Term) bool {
+	return len(t.FreeVars()) == 0
+}
+
+// FreeVarNames returns the free variables of t, sorted.
+func FreeVarNames(t Term) []string {
+	fv := t.FreeVars()
+	names := make([]string, 0, len(fv))
+	for name := range fv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClosedError reports a term expected to be closed that has free variables.
+type ClosedError struct {
+	Free []string // Sorted names of the free variables
+}
+
+func (e *ClosedError) Error() string {
+	return "term is not closed: free variables " + strings.Join(e.Free, ", ")
+}
+
+// Size returns the number of nodes in t: each variable, abstraction,
+// application and numeral counts as one. LazyScripts count as the size of
+// their parsed term.
+func Size(t Term) int {
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		return 1 + Size(t.Body)
+	case Application:
+		return 1 + Size(t.Func) + Size(t.Arg)
+	case NumeralApply:
+		return 1 + Size(t.F)
+	}
+	return 1
+}
+
+// ErrTooDeep is returned when a term is nested deeper than allowed.
+var ErrTooDeep = errors.New("term too deep")
+
+// Depth returns the nesting depth of t: 1 for a variable or numeral, and
+// one more than the deepest child for abstractions and applications. Most
+// functions of this package recurse over terms, so a term of depth in the
+// millions, such as the numeral _9999999, can exhaust the goroutine stack;
+// checking Depth first is safe, as it does not recurse.
+func Depth(t Term) int {
+	type frame struct {
+		term  Term
+		depth int
+	}
+	max := 0
+	stack := []frame{{t, 1}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.depth > max {
+			max = f.depth
+		}
+		switch t := unwrap(f.term).(type) {
+		case Abstraction:
+			stack = append(stack, frame{t.Body, f.depth + 1})
+		case Application:
+			stack = append(stack, frame{t.Func, f.depth + 1}, frame{t.Arg, f.depth + 1})
+		case NumeralApply:
+			stack = append(stack, frame{t.F, f.depth + 1})
+		}
+	}
+	return max
+}
+
+// IsCombinatory reports whether t is a term of combinatory logic: it
+// contains no abstraction, only variables (standing for combinators such as
+// S and K, or free variables) and applications of them. Compact numerals
+// count as abstractions.
+func IsCombinatory(t Term) bool {
+	switch t := unwrap(t).(type) {
+	case Var:
+		return true
+	case Application:
+		return IsCombinatory(t.Func) && IsCombinatory(t.Arg)
+	}
+	return false
+}
+
+// divergentTerms lists the terms LikelyDiverges looks for.
+var divergentTerms = []Term{
+	OMEGA,                        // (λx.x x) (λx.x x)
+	Application{Func: Y, Arg: I}, // Y I
+}
+
+// LikelyDiverges reports whether t certainly has no normal form, because a
+// subterm α-equivalent to Ω or to Y I sits in a position that normal order
+// reduction will force: the head of the term, the body of an abstraction
+// that is not applied, or an argument of a variable that no reduction can
+// replace. It is a cheap, conservative check to reject obvious
+// non-terminating input before reducing it, not a solution to the halting
+// problem: false negatives are expected, as most divergent terms, such as
+// FACTORIAL applied to a non-numeral, are not recognized. A true result is
+// always right.
+func LikelyDiverges(t Term) bool {
+	found := false
+	for _, d := range divergentTerms {
+		if Contains(t, d) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	return forcedDivergence(t, nil)
+}
+
+// forcedDivergence reports whether a divergent term is forced in t.
+// substituted holds the variables bound by abstractions being applied,
+// which reduction replaces by arbitrary arguments.
+func forcedDivergence(t Term, substituted map[string]bool) bool {
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		return forcedDivergence(t.Body, without(substituted, t.Param))
+	case Application:
+		var args []Term
+		var head Term = t
+		for {
+			app, ok := unwrap(head).(Application)
+			if !ok {
+				break
+			}
+			for _, d := range divergentTerms {
+				if AlphaEquivalent(app, d) {
+					return true
+				}
+			}
+			args = append(args, app.Arg)
+			head = app.Func
+		}
+
+		switch h := unwrap(head).(type) {
+		case Var:
+			if substituted[h.Name] {
+				return false
+			}
+			// Nothing replaces the head, so every argument is normalized
+			for _, arg := range args {
+				if forcedDivergence(arg, substituted) {
+					return true
+				}
+			}
+		case Abstraction:
+			// The body ends up in head position once the arguments, in
+			// reverse order in args, are substituted
+			body := Term(h)
+			for range args {
+				abs, ok := unwrap(body).(Abstraction)
+				if !ok {
+					break
+				}
+				substituted = with(substituted, abs.Param)
+				body = abs.Body
+			}
+			return forcedDivergence(body, substituted)
+		}
+	}
+	return false
+}
+
+// with returns a copy of set with name added.
+func with(set map[string]bool, name string) map[string]bool {
+	c := make(map[string]bool, len(set)+1)
+	for k := range set {
+		c[k] = true
+	}
+	c[name] = true
+	return c
+}
+
+// without returns set with name removed, copying it only if needed.
+func without(set map[string]bool, name string) map[string]bool {
+	if !set[name] {
+		return set
+	}
+	c := make(map[string]bool, len(set))
+	for k := range set {
+		if k != name {
+			c[k] = true
+		}
+	}
+	return c
+}
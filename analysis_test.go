@@ -0,0 +1,210 @@
+package lambda
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFreeVarCounts(t *testing.T) {
+	tests := []struct {
+		input string
+		want  map[string]int
+	}{
+		{"x x y", map[string]int{"x": 2, "y": 1}},
+		{"λf.λx.f (f x)", map[string]int{}},
+		{"λx.x y (λy.y) y", map[string]int{"y": 2}},
+		{"(λx.x) x", map[string]int{"x": 1}},
+		{"_PLUS a b", map[string]int{"a": 1, "b": 1}},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		if got := FreeVarCounts(term); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FreeVarCounts(%s) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	// λx.f^3 x uses f three times
+	na := NumeralApply{N: 3, Param: "x", F: Var{Name: "f"}}
+	if got := FreeVarCounts(na); got["f"] != 3 || len(got) != 1 {
+		t.Errorf("FreeVarCounts(%s) = %v, want map[f:3]", na, got)
+	}
+	if got := FreeVarCounts(NumeralApply{N: 0, Param: "x", F: Var{Name: "f"}}); len(got) != 0 {
+		t.Errorf("FreeVarCounts(λx.f^0 x) = %v, want an empty map", got)
+	}
+}
+
+func TestIsClosed(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"λx.x", []string{}},
+		{"λf.λx.f (f x)", []string{}},
+		{"λx.y x z", []string{"y", "z"}},
+		{"λrec.λn.n (rec n) (recc n)", []string{"recc"}},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		if got := IsClosed(term); got != (len(tt.want) == 0) {
+			t.Errorf("IsClosed(%s) = %v", tt.input, got)
+		}
+		if got := FreeVarNames(term); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FreeVarNames(%s) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseRequireClosed(t *testing.T) {
+	p := &Parser{RequireClosed: true}
+
+	if _, err := p.Parse("λx.λy.x"); err != nil {
+		t.Errorf("closed term rejected: %v", err)
+	}
+
+	_, err := p.Parse("λx.z x y")
+	var closedErr *ClosedError
+	if !errors.As(err, &closedErr) {
+		t.Fatalf("err = %v, want *ClosedError", err)
+	}
+	if !reflect.DeepEqual(closedErr.Free, []string{"y", "z"}) {
+		t.Errorf("Free = %v, want [y z]", closedErr.Free)
+	}
+	if err.Error() != "term is not closed: free variables y, z" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestConstantsClosed(t *testing.T) {
+	// A mis-bound variable in a constant's script would show up as free
+	for name, c := range map[string]Term{
+		"Y": Y, "FACTORIAL": FACTORIAL, "FIB": FIB, "GCD": GCD, "MOD": MOD,
+		"POWMOD": POWMOD, "POWMOD_PRIME": POWMOD_PRIME, "IS_PRIME": IS_PRIME,
+		"MAP": MAP, "FOLDR": FOLDR, "FOLDL": FOLDL, "RANGE": RANGE,
+		"SELF_EVAL": SELF_EVAL, "SINT_SUB": SINT_SUB, "ITERSTATE": ITERSTATE,
+	} {
+		if !IsClosed(c) {
+			t.Errorf("%s has free variables %v", name, FreeVarNames(c))
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		term Term
+		want int
+	}{
+		{Var{Name: "x"}, 1},
+		{I, 2},
+		{K, 3},
+		{ChurchNumeral(2), 7},
+		{Numeral(100), 1},
+		{NumeralApply{N: 3, Param: "x", F: Var{Name: "f"}}, 2},
+	}
+
+	for _, tt := range tests {
+		if got := Size(tt.term); got != tt.want {
+			t.Errorf("Size(%s) = %d, want %d", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestIsCombinatory(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"S K K", true},
+		{"S (K S) K x", true},
+		{"x", true},
+		{"λx.x", false},
+		{"S K (λx.x)", false},
+		{"_K", false}, // the constant is defined by an abstraction
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if got := IsCombinatory(term); got != tt.want {
+			t.Errorf("IsCombinatory(%s) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	if IsCombinatory(Numeral(2)) {
+		t.Error("IsCombinatory(Numeral(2)) = true, want false")
+	}
+}
+
+func TestDepth(t *testing.T) {
+	tests := []struct {
+		term Term
+		want int
+	}{
+		{Var{Name: "x"}, 1},
+		{I, 2},
+		{ChurchNumeral(3), 6},
+		{Numeral(3), 1},
+		{Application{Func: Var{Name: "f"}, Arg: ChurchNumeral(1)}, 5},
+	}
+	for _, tt := range tests {
+		if got := Depth(tt.term); got != tt.want {
+			t.Errorf("Depth(%s) = %d, want %d", tt.term, got, tt.want)
+		}
+	}
+
+	// Deep enough that a recursive walk would need a large stack
+	if got := Depth(ChurchNumeral(1000000)); got != 1000003 {
+		t.Errorf("Depth(1000000) = %d, want 1000003", got)
+	}
+}
+
+func TestLikelyDiverges(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`_OMEGA`, true},
+		{`_U _U`, true},
+		{`(λa.a a) (λb.b b)`, true},
+		{`_Y _I`, true},
+		{`λz.(λx.x x) (λx.x x)`, true},
+		{`_OMEGA y`, true},
+		{`f (_Y _I)`, true},
+		{`(λx.λy._OMEGA) a b`, true},
+		{`λf.f _OMEGA`, true},
+
+		// The divergent subterm is discarded or not forced
+		{`_K a _OMEGA`, false},
+		{`_FALSE _OMEGA a`, false},
+		{`(λx.x _OMEGA) (_K _I)`, false},
+		{`_U`, false},
+		{`_FACTORIAL _3`, false},
+		// Divergent but not recognized
+		{`_Y _K`, false},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := LikelyDiverges(term); got != tt.want {
+			t.Errorf("LikelyDiverges(%s) = %v, want %v", tt.expr, got, tt.want)
+		}
+		if tt.want {
+			if _, ok := normalForm(term, 200); ok {
+				t.Errorf("%s has a normal form", tt.expr)
+			}
+		}
+	}
+}
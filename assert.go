@@ -0,0 +1,23 @@
+package lambda
+
+import (
+	"testing"
+)
+
+// AssertEquivalent fails the test unless a and b have α-equivalent
+// βη-normal forms, each computed with Normalize in at most limit steps.
+// The failure message shows both normal forms.
+func AssertEquivalent(t testing.TB, a, b Term, limit int) {
+	t.Helper()
+
+	na, okA := Normalize(a, limit)
+	nb, okB := Normalize(b, limit)
+	switch {
+	case !okA:
+		t.Errorf("%s has no normal form within %d steps (reached %s)", a, limit, na)
+	case !okB:
+		t.Errorf("%s has no normal form within %d steps (reached %s)", b, limit, nb)
+	case !AlphaEquivalent(na, nb):
+		t.Errorf("terms are not equivalent:\n  %s\n→ %s\nand\n  %s\n→ %s", a, na, b, nb)
+	}
+}
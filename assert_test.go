@@ -0,0 +1,57 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+)
+
+// recordingTB captures failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, format)
+}
+
+func TestAssertEquivalent(t *testing.T) {
+	// 2 * 3 = 6
+	AssertEquivalent(t, Application{Func: Application{Func: MULT, Arg: ChurchNumeral(2)}, Arg: ChurchNumeral(3)}, ChurchNumeral(6), 100)
+	// factorial(3) = 6
+	AssertEquivalent(t, Application{Func: FACTORIAL, Arg: ChurchNumeral(3)}, ChurchNumeral(6), 2000)
+	// η: λx.f x = f
+	AssertEquivalent(t, Abstraction{Param: "x", Body: Application{Func: Var{Name: "f"}, Arg: Var{Name: "x"}}}, Var{Name: "f"}, 10)
+}
+
+func TestAssertEquivalentFails(t *testing.T) {
+	r := &recordingTB{TB: t}
+	AssertEquivalent(r, TRUE, FALSE, 10)
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "not equivalent") {
+		t.Errorf("TRUE and FALSE: got errors %v", r.errors)
+	}
+
+	r = &recordingTB{TB: t}
+	AssertEquivalent(r, OMEGA, I, 10)
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "no normal form") {
+		t.Errorf("Ω and I: got errors %v", r.errors)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// λx.(λy.f y) x β-reduces to λx.f x and η-reduces to f
+	term, err := Parse("λx.(λy.f y) x")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	nf, ok := Normalize(term, 10)
+	if !ok || nf.String() != "f" {
+		t.Errorf("Normalize = %s, %v; want f, true", nf, ok)
+	}
+
+	if _, ok := Normalize(OMEGA, 10); ok {
+		t.Error("Ω should not normalize")
+	}
+}
@@ -0,0 +1,28 @@
+package lambda
+
+// ToAST returns the structure of a term as nested Go values, for tooling
+// such as template engines and inspectors that work on generic data.
+//
+// Each node is a map[string]any with a "kind" key:
+//   - Var:          {"kind": "var", "name": string}
+//   - Abstraction:  {"kind": "abs", "param": string, "body": node}
+//   - Application:  {"kind": "app", "func": node, "arg": node}
+//   - Numeral:      {"kind": "numeral", "value": uint64}
+//   - NumeralApply: {"kind": "numeral_apply", "n": uint64, "param": string, "f": node}
+//
+// LazyScript values are replaced by their parsed term.
+func ToAST(t Term) any {
+	switch term := unwrap(t).(type) {
+	case Var:
+		return map[string]any{"kind": "var", "name": term.Name}
+	case Abstraction:
+		return map[string]any{"kind": "abs", "param": term.Param, "body": ToAST(term.Body)}
+	case Application:
+		return map[string]any{"kind": "app", "func": ToAST(term.Func), "arg": ToAST(term.Arg)}
+	case Numeral:
+		return map[string]any{"kind": "numeral", "value": uint64(term)}
+	case NumeralApply:
+		return map[string]any{"kind": "numeral_apply", "n": term.N, "param": term.Param, "f": ToAST(term.F)}
+	}
+	return nil
+}
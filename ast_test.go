@@ -0,0 +1,42 @@
+package lambda
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToASTIdentity(t *testing.T) {
+	want := map[string]any{
+		"kind":  "abs",
+		"param": "x",
+		"body":  map[string]any{"kind": "var", "name": "x"},
+	}
+	if got := ToAST(I); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToAST(I) = %#v, want %#v", got, want)
+	}
+}
+
+func TestToASTApplication(t *testing.T) {
+	term := Application{Func: Var{Name: "f"}, Arg: Numeral(3)}
+	want := map[string]any{
+		"kind": "app",
+		"func": map[string]any{"kind": "var", "name": "f"},
+		"arg":  map[string]any{"kind": "numeral", "value": uint64(3)},
+	}
+	if got := ToAST(term); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToAST(f [3]) = %#v, want %#v", got, want)
+	}
+}
+
+func TestToASTNumeralApply(t *testing.T) {
+	term := NumeralApply{N: 2, Param: "x", F: Var{Name: "g"}}
+	want := map[string]any{
+		"kind":  "numeral_apply",
+		"n":     uint64(2),
+		"param": "x",
+		"f":     map[string]any{"kind": "var", "name": "g"},
+	}
+	if got := ToAST(term); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToAST(NumeralApply) = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+package lambda
+
+import (
+	"sort"
+)
+
+// benchTerms is the standard benchmark corpus, by name.
+// Names are the operation followed by its arguments.
+var benchTerms = map[string]string{
+	"plus_10_10":  "_PLUS _10 _10",
+	"mult_5_5":    "_MULT _5 _5",
+	"pow_2_5":     "_POW _2 _5",
+	"sub_5_2":     "_SUB _5 _2",
+	"factorial_3": "_FACTORIAL _3",
+	"fib_6":       "_FIB _6",
+	"gcd_6_4":     "_GCD _6 _4",
+	"map_succ_3":  "_MAP _SUCC (_RANGE _3)",
+}
+
+// BenchTerm returns a canonical workload for benchmarks, so that benchmarks
+// in this and other packages share the same corpus. It returns nil for an
+// unknown name; see BenchTermNames for the available workloads.
+func BenchTerm(name string) Term {
+	script, ok := benchTerms[name]
+	if !ok {
+		return nil
+	}
+	t, err := Parse(script)
+	if err != nil {
+		panic("invalid benchmark term " + name + ": " + err.Error())
+	}
+	return t
+}
+
+// BenchTermNames returns the names of all benchmark workloads, sorted.
+func BenchTermNames() []string {
+	names := make([]string, 0, len(benchTerms))
+	for name := range benchTerms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,47 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestBenchTerm(t *testing.T) {
+	want := map[string]int{
+		"plus_10_10":  20,
+		"mult_5_5":    25,
+		"pow_2_5":     32,
+		"sub_5_2":     3,
+		"factorial_3": 6,
+		"fib_6":       8,
+		"gcd_6_4":     2,
+	}
+
+	for _, name := range BenchTermNames() {
+		term := BenchTerm(name)
+		if term == nil {
+			t.Fatalf("BenchTerm(%q) = nil", name)
+		}
+		n, ok := want[name]
+		if !ok {
+			continue
+		}
+		result, _ := Reduce(term, 100000)
+		if got := ToInt(result); got != n {
+			t.Errorf("%s = %d, want %d", name, got, n)
+		}
+	}
+
+	if BenchTerm("nonexistent") != nil {
+		t.Error("BenchTerm of an unknown name should be nil")
+	}
+}
+
+func BenchmarkCorpus(b *testing.B) {
+	for _, name := range BenchTermNames() {
+		term := BenchTerm(name)
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Reduce(term, 100000)
+			}
+		})
+	}
+}
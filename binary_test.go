@@ -0,0 +1,71 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestDoubleHalve(t *testing.T) {
+	for n := 0; n <= 10; n++ {
+		if got, ok := Double(n, 0); !ok || got != 2*n {
+			t.Errorf("Double(%d) = %d, %v, want %d", n, got, ok, 2*n)
+		}
+		if got, ok := Halve(n, 0); !ok || got != n/2 {
+			t.Errorf("Halve(%d) = %d, %v, want %d", n, got, ok, n/2)
+		}
+	}
+}
+
+func TestDoubleHalveParsed(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"_DOUBLE _5", 10},
+		{"_HALVE _10", 5},
+		{"_HALVE _7", 3},
+		{"_HALVE (_DOUBLE _4)", 4},
+		// n = 2 (n / 2) + n mod 2
+		{"_PLUS (_DOUBLE (_HALVE _7)) (_B2N (_ISODD _7))", 7},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		reduced, _ := Reduce(expr, 5000)
+		if got := ToInt(reduced); got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPOPCOUNT(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"_POPCOUNT _0", 0}, // 12 steps
+		{"_POPCOUNT _7", 3}, // 3068 steps
+		{"_POPCOUNT _8", 1}, // 9357 steps
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		reduced, _ := Reduce(expr, 20000)
+		if got := ToInt(reduced); got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPopcount(t *testing.T) {
+	for n, want := range []int{0, 1, 1, 2, 1, 2} {
+		if got, ok := Popcount(n, 20000); !ok || got != want {
+			t.Errorf("Popcount(%d) = %d, %v, want %d", n, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package lambda
+
+// Combinator birds
+//
+// Raymond Smullyan's "To Mock a Mockingbird" names combinators after birds.
+// The birds below are aliases for the combinators defined elsewhere in the
+// package, or new terms where there is none.
+//
+// The Thrush is written T in the book, but T is already the conventional
+// abbreviation of TRUE (the Kestrel). T keeps meaning TRUE here, and the
+// Thrush is only available as THRUSH (_THRUSH in expressions).
+var (
+	IDIOT    = I    // I := λx.x
+	KESTREL  = K    // K := λx.λy.x, also TRUE
+	STARLING = S    // S := λx.λy.λz.x z (y z)
+	BLUEBIRD = B    // B := λx.λy.λz.x (y z)
+	CARDINAL = C    // C := λx.λy.λz.x z y
+	WARBLER  = W    // W := λx.λy.x y y
+	VIREO    = PAIR // V := λx.λy.λf.f x y
+
+	// M := λx.x x (Mockingbird), the same as U
+	M           = U
+	MOCKINGBIRD = U
+
+	// KI := λx.λy.y (Kite), the same as FALSE
+	KITE = FALSE
+
+	// T := λx.λf.f x (Thrush), equal to C I
+	THRUSH = MakeLazyScript(`λx.λf.f x`)
+
+	// R := λx.λy.λz.y z x (Robin), equal to B B T
+	ROBIN = MakeLazyScript(`λx.λy.λz.y z x`)
+
+	// L := λx.λy.x (y y) (Lark)
+	LARK = MakeLazyScript(`λx.λy.x (y y)`)
+)
+
+// birds lists the birds recognized by BirdName, each one once
+var birds = []struct {
+	name string
+	term Term
+}{
+	{"IDIOT", IDIOT},
+	{"KESTREL", KESTREL},
+	{"KITE", KITE},
+	{"STARLING", STARLING},
+	{"BLUEBIRD", BLUEBIRD},
+	{"CARDINAL", CARDINAL},
+	{"WARBLER", WARBLER},
+	{"VIREO", VIREO},
+	{"MOCKINGBIRD", MOCKINGBIRD},
+	{"THRUSH", THRUSH},
+	{"ROBIN", ROBIN},
+	{"LARK", LARK},
+}
+
+// BirdName reports which bird t is, by comparing its normal form, reached
+// in at most limit steps, with the birds of this package up to
+// α-equivalence. For instance C I is the Thrush and S K K the Idiot. The
+// name is returned without underscore, as in "THRUSH".
+// If limit is 0 or negative, DefaultStepLimit is used.
+func BirdName(t Term, limit int) (string, bool) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	nf, ok := normalForm(t, limit)
+	if !ok {
+		return "", false
+	}
+	for _, bird := range birds {
+		if AlphaEquivalent(nf, bird.term) {
+			return bird.name, true
+		}
+	}
+	return "", false
+}
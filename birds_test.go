@@ -0,0 +1,59 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestBirdName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`_I`, "IDIOT"},
+		{`_S _K _K`, "IDIOT"},
+		{`_C _I`, "THRUSH"},
+		{`_B _B _THRUSH`, "ROBIN"},
+		{`_TRUE`, "KESTREL"},
+		{`_FALSE`, "KITE"},
+		{`_K _I`, "KITE"},
+		{`_M`, "MOCKINGBIRD"},
+		{`λa.λb.a (b b)`, "LARK"},
+		{`_PAIR`, "VIREO"},
+		{`_STARLING`, "STARLING"},
+		{`_BLUEBIRD`, "BLUEBIRD"},
+		{`_CARDINAL`, "CARDINAL"},
+		{`_WARBLER`, "WARBLER"},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		name, ok := BirdName(term, 0)
+		if !ok || name != tt.expected {
+			t.Errorf("BirdName(%s) = %q, %v, expected %q", tt.input, name, ok, tt.expected)
+		}
+	}
+}
+
+func TestBirdNameUnknown(t *testing.T) {
+	for _, input := range []string{`x`, `_2`, `_OMEGA`} {
+		term, _ := Parse(input)
+		if name, ok := BirdName(term, 100); ok {
+			t.Errorf("BirdName(%s) = %q, expected no bird", input, name)
+		}
+	}
+}
+
+func TestThrushIsNotT(t *testing.T) {
+	// _T keeps meaning TRUE, the Thrush is _THRUSH
+	tTerm, _ := Parse(`_T`)
+	thrush, _ := Parse(`_THRUSH`)
+	if !AlphaEquivalent(tTerm, TRUE) {
+		t.Errorf("_T = %s, expected TRUE", tTerm)
+	}
+	if AlphaEquivalent(thrush, TRUE) {
+		t.Errorf("_THRUSH should differ from TRUE")
+	}
+}
@@ -0,0 +1,39 @@
+package lambda
+
+// Term builders
+//
+// V, Lam and App build terms in Go without nested struct literals:
+//
+//	S := Lam("x", Lam("y", Lam("z", App(V("x"), V("z"), App(V("y"), V("z"))))))
+//
+// They return the plain Var, Abstraction and Application values, which can
+// still be written out directly.
+
+// V returns the variable name.
+func V(name string) Term {
+	return Var{Name: name}
+}
+
+// Lam returns the abstraction λparam.body.
+func Lam(param string, body Term) Term {
+	return Abstraction{Param: param, Body: body}
+}
+
+// App applies f to args in turn, so App(f, a, b) is (f a) b. With no
+// arguments it returns f.
+func App(f Term, args ...Term) Term {
+	for _, arg := range args {
+		f = Application{Func: f, Arg: arg}
+	}
+	return f
+}
+
+// Apply left-associates terms into nested applications, so
+// Apply(f, x, y, z) is ((f x) y) z. A single term is returned unchanged.
+// Apply panics if terms is empty.
+func Apply(terms ...Term) Term {
+	if len(terms) == 0 {
+		panic("lambda: Apply needs at least one term")
+	}
+	return App(terms[0], terms[1:]...)
+}
@@ -0,0 +1,56 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		built Term
+		want  string
+	}{
+		{V("x"), "x"},
+		{Lam("x", V("x")), `\x.x`},
+		{App(V("f")), "f"},
+		{App(V("f"), V("a"), V("b")), "f a b"},
+		{App(V("f"), App(V("a"), V("b"))), "f (a b)"},
+		{Lam("x", Lam("y", Lam("z", App(V("x"), V("z"), App(V("y"), V("z")))))), `\x.\y.\z.x z (y z)`},
+		{App(PLUS, ChurchNumeral(1), ChurchNumeral(2)), "_PLUS _1 _2"},
+	}
+
+	for _, tt := range tests {
+		want, err := Parse(tt.want)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.want, err)
+		}
+		if !Equal(tt.built, want) {
+			t.Errorf("built %s, want %s", tt.built, tt.want)
+		}
+	}
+
+	// The builders return the plain struct types
+	if _, ok := App(V("f"), V("x")).(Application); !ok {
+		t.Errorf("App does not return an Application")
+	}
+	if _, ok := Lam("x", V("x")).(Abstraction); !ok {
+		t.Errorf("Lam does not return an Abstraction")
+	}
+}
+
+func TestApply(t *testing.T) {
+	f, x, y, z := V("f"), V("x"), V("y"), V("z")
+	want := Application{Func: Application{Func: Application{Func: f, Arg: x}, Arg: y}, Arg: z}
+	if got := Apply(f, x, y, z); got != Term(want) {
+		t.Errorf("Apply(f, x, y, z) = %s, want %s", got, want)
+	}
+	if got := Apply(f); got != f {
+		t.Errorf("Apply(f) = %s, want f", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Apply() did not panic")
+		}
+	}()
+	Apply()
+}
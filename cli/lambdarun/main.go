@@ -1,168 +1,172 @@
 package main
 
 import (
+	"encoding/csv"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 
 	lambda "github.com/KarpelesLab/lambda"
 )
 
 func main() {
-	maxSteps := flag.Int("steps", 10000, "Maximum number of beta reduction steps")
-	outputType := flag.String("type", "auto", "Output type: auto, int, bool, lambda")
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] <expression>\n\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Evaluates a lambda calculus expression and prints the result.\n\n")
-		fmt.Fprintf(os.Stderr, "Options:\n")
-		flag.PrintDefaults()
-		fmt.Fprintf(os.Stderr, "\nExamples:\n")
-		fmt.Fprintf(os.Stderr, "  %s '_PLUS _2 _3'\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -type bool '_AND _TRUE _FALSE'\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -steps 1000 '(\\x. x) _5'\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -type bool '_LEQ _2 _3'\n", os.Args[0])
+	os.Exit(run(os.Args, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments, args[0] being the
+// program name, and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	name := args[0]
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	maxSteps := flags.Int("steps", 10000, "Maximum number of beta reduction steps")
+	outputType := flags.String("type", "auto", "Output type: auto, int, bool, lambda")
+	strategyName := flags.String("strategy", "normal", "Reduction strategy: normal or applicative (applicative may not terminate where normal does)")
+	sizeCSV := flags.Bool("sizecsv", false, "Print the term size at each step as step,size CSV instead of the result")
+	render := flags.String("render", "", "Render the unreduced term as a diagram instead of evaluating it: svg, text or tikz")
+	output := flags.String("o", "-", "Output file for -render, - for stdout")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s [options] <expression>\n\n", name)
+		fmt.Fprintf(stderr, "Evaluates a lambda calculus expression and prints the result.\n\n")
+		fmt.Fprintf(stderr, "Options:\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(stderr, "\nExamples:\n")
+		fmt.Fprintf(stderr, "  %s '_PLUS _2 _3'\n", name)
+		fmt.Fprintf(stderr, "  %s -type bool '_AND _TRUE _FALSE'\n", name)
+		fmt.Fprintf(stderr, "  %s -steps 1000 '(\\x. x) _5'\n", name)
+		fmt.Fprintf(stderr, "  %s -type bool '_LEQ _2 _3'\n", name)
+		fmt.Fprintf(stderr, "  %s -strategy applicative '(\\x.\\y.y) ((\\x.x x) (\\x.x x))'\n", name)
+		fmt.Fprintf(stderr, "  %s -sizecsv '_MULT _2 _3' > sizes.csv\n", name)
+		fmt.Fprintf(stderr, "  %s -render svg -o church2.svg '\\f.\\x.f (f x)'\n", name)
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 1
 	}
-	flag.Parse()
 
-	if flag.NArg() != 1 {
-		flag.Usage()
-		os.Exit(1)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 1
 	}
 
-	input := flag.Arg(0)
+	input := flags.Arg(0)
 
 	// Parse the expression
 	expr, err := lambda.Parse(input)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Parse error: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(stderr, "Parse error: %v\n", err)
+		return 1
 	}
 
-	// Reduce the expression
-	result, steps := lambda.Reduce(expr, *maxSteps)
-
-	// Check if we hit the step limit
-	if steps >= *maxSteps {
-		fmt.Fprintf(os.Stderr, "Warning: Reached maximum step limit (%d steps)\n", *maxSteps)
-		fmt.Fprintf(os.Stderr, "Result may be partially reduced.\n\n")
+	if *render != "" {
+		diagram, err := renderDiagram(expr, *render)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := writeOutput(*output, stdout, diagram); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
 	}
 
-	// Handle output based on requested type
-	switch *outputType {
-	case "bool":
-		// Force interpretation as boolean
-		if b, ok := tryToBool(result); ok {
-			fmt.Printf("%v\n", b)
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: Result is not a valid Church boolean\n")
-			fmt.Printf("%s\n", result)
-			os.Exit(1)
+	if *sizeCSV {
+		profile := lambda.SizeProfile(expr, *maxSteps)
+		if err := writeSizeCSV(stdout, profile); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
 		}
-
-	case "int":
-		// Force interpretation as integer
-		if n, ok := tryToInt(result); ok {
-			fmt.Printf("%d\n", n)
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: Result is not a valid Church numeral\n")
-			fmt.Printf("%s\n", result)
-			os.Exit(1)
+		if steps := len(profile) - 1; steps >= *maxSteps {
+			fmt.Fprintf(stderr, "Warning: Reached maximum step limit (%d steps)\n", *maxSteps)
 		}
+		return 0
+	}
 
-	case "lambda":
-		// Always show lambda expression
-		fmt.Printf("%s\n", result)
-
+	// The lambda package uses an empty kind for automatic detection
+	kind := *outputType
+	switch kind {
 	case "auto":
-		// Try int first (since most operations produce numbers)
-		if n, ok := tryToInt(result); ok {
-			fmt.Printf("%d\n", n)
-		} else if b, ok := tryToBool(result); ok {
-			// Note: This won't be reached for 0/1 since they're valid ints
-			fmt.Printf("%v\n", b)
-		} else {
-			// Show lambda expression
-			fmt.Printf("%s\n", result)
-		}
-
+		kind = ""
+	case lambda.KindInt, lambda.KindBool, lambda.KindLambda:
 	default:
-		fmt.Fprintf(os.Stderr, "Error: Invalid output type %q (must be: auto, int, bool, lambda)\n", *outputType)
-		os.Exit(1)
+		fmt.Fprintf(stderr, "Error: Invalid output type %q (must be: auto, int, bool, lambda)\n", *outputType)
+		return 1
 	}
 
-	if steps < *maxSteps {
-		fmt.Fprintf(os.Stderr, "Reduced in %d steps\n", steps)
+	strategy, err := lambda.ParseStrategy(*strategyName)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
 	}
-}
 
-// tryToInt attempts to interpret a Term as a Church numeral
-// Returns the integer value and true if successful, or 0 and false otherwise
-func tryToInt(obj lambda.Term) (int, bool) {
-	// Church numerals have the form: λf.λx. f (f (f ... (f x)))
-	// Try to extract the number of applications
-	abs1, ok := obj.(lambda.Abstraction)
-	if !ok {
-		return 0, false
-	}
-
-	abs2, ok := abs1.Body.(lambda.Abstraction)
-	if !ok {
-		return 0, false
-	}
-
-	// Count the number of times the first parameter (f) is applied
-	count := 0
-	current := abs2.Body
-
-	for {
-		app, ok := current.(lambda.Application)
-		if !ok {
-			// Should end with the second parameter (x)
-			if v, ok := current.(lambda.Var); ok && v.Name == abs2.Param {
-				return count, true
-			}
-			return 0, false
-		}
-
-		// Check if function is the first parameter (f)
-		if v, ok := app.Func.(lambda.Var); ok && v.Name == abs1.Param {
-			count++
-			current = app.Arg
-		} else {
-			return 0, false
-		}
+	res := lambda.EvalTermWithStrategy(expr, kind, *maxSteps, strategy)
+	if !res.Complete {
+		fmt.Fprintf(stderr, "Warning: Reached maximum step limit (%d steps)\n", *maxSteps)
+		fmt.Fprintf(stderr, "Result may be partially reduced.\n\n")
 	}
-}
 
-// tryToBool attempts to interpret a Term as a Church boolean
-// Returns the boolean value and true if successful, or false and false otherwise
-func tryToBool(obj lambda.Term) (bool, bool) {
-	// Church booleans have the form:
-	// TRUE  = λx.λy.x
-	// FALSE = λx.λy.y
+	if res.Err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", res.Err)
+	}
+	switch res.Kind {
+	case lambda.KindInt:
+		fmt.Fprintf(stdout, "%d\n", res.IntValue)
+	case lambda.KindBool:
+		fmt.Fprintf(stdout, "%v\n", res.BoolValue)
+	default:
+		fmt.Fprintf(stdout, "%s\n", res.Term)
+	}
 
-	abs1, ok := obj.(lambda.Abstraction)
-	if !ok {
-		return false, false
+	if res.Complete && res.Err == nil {
+		fmt.Fprintf(stderr, "Reduced in %d steps\n", res.Steps)
 	}
+	return exitCode(res)
+}
 
-	abs2, ok := abs1.Body.(lambda.Abstraction)
-	if !ok {
-		return false, false
+// exitCode maps an evaluation result to the exit code of the command: 1
+// if the result is not of the requested type, 0 otherwise. Reaching the
+// step limit only prints a warning.
+func exitCode(res lambda.EvalResult) int {
+	if res.Err != nil {
+		return 1
 	}
+	return 0
+}
 
-	// The body should be a variable
-	v, ok := abs2.Body.(lambda.Var)
-	if !ok {
-		return false, false
+// renderDiagram renders a term as a Tromp diagram in the given format
+func renderDiagram(expr lambda.Term, format string) (string, error) {
+	switch format {
+	case "svg":
+		return lambda.DiagramSVG(expr, nil), nil
+	case "text":
+		return lambda.Diagram(expr) + "\n", nil
+	case "tikz":
+		return lambda.ToTikZ(lambda.BuildSVGDiagram(expr, nil)), nil
 	}
+	return "", fmt.Errorf("invalid render format %q (must be: svg, text, tikz)", format)
+}
 
-	// Check which parameter it returns
-	if v.Name == abs1.Param {
-		return true, true
-	} else if v.Name == abs2.Param {
-		return false, true
+// writeOutput writes data to the named file, or to stdout if name is -
+func writeOutput(name string, stdout io.Writer, data string) error {
+	if name == "-" {
+		_, err := io.WriteString(stdout, data)
+		return err
 	}
+	return os.WriteFile(name, []byte(data), 0644)
+}
 
-	return false, false
-}
\ No newline at end of file
+// writeSizeCSV writes a size profile as CSV with a step,size header
+func writeSizeCSV(w io.Writer, profile []int) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"step", "size"})
+	for step, size := range profile {
+		cw.Write([]string{strconv.Itoa(step), strconv.Itoa(size)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	lambda "github.com/KarpelesLab/lambda"
+)
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lambdarun", "_PLUS _2 _3"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "5\n" {
+		t.Errorf("stdout = %q, want %q", stdout.String(), "5\n")
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := run([]string{"lambdarun", "(λx.x"}, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code %d for a parse error, want 1", code)
+	}
+}
+
+func TestRunSizeCSV(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lambdarun", "-sizecsv", "_PLUS _1 _1"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+
+	// _PLUS _1 _1 reduces in 6 steps
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 1+7 {
+		t.Fatalf("got %d lines, want a header and 7 rows:\n%s", len(lines), stdout.String())
+	}
+	if lines[0] != "step,size" {
+		t.Errorf("header = %q, want step,size", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "0,") || !strings.HasPrefix(lines[7], "6,") {
+		t.Errorf("rows do not number the steps from 0 to 6:\n%s", stdout.String())
+	}
+}
+
+func TestRunForcedType(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lambdarun", "-type", "bool", "_AND _TRUE _FALSE"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "false\n" {
+		t.Errorf("stdout = %q, want %q", stdout.String(), "false\n")
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := run([]string{"lambdarun", "-type", "int", "λx.x"}, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code %d for a non-numeral with -type int, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "not a valid Church numeral") {
+		t.Errorf("stderr = %q, want a numeral error", stderr.String())
+	}
+}
+
+func TestRunStrategy(t *testing.T) {
+	const expr = "(λx.λy.y) ((λx.x x) (λx.x x))"
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lambdarun", "-strategy", "normal", "-type", "lambda", expr}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "λy.y\n" || !strings.Contains(stderr.String(), "Reduced in 1 steps") {
+		t.Errorf("normal order: stdout %q, stderr %q", stdout.String(), stderr.String())
+	}
+
+	// Applicative order reduces the argument first and never terminates
+	stdout.Reset()
+	stderr.Reset()
+	if code := run([]string{"lambdarun", "-strategy", "applicative", "-steps", "50", "-type", "lambda", expr}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "maximum step limit (50 steps)") {
+		t.Errorf("applicative order did not reach the step limit: stderr %q", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := run([]string{"lambdarun", "-strategy", "lazy", "_1"}, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code %d for an invalid strategy, want 1", code)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	expr, _ := lambda.Parse("λx.x")
+	res := lambda.EvalTerm(expr, lambda.KindInt, 100)
+	if res.Err == nil {
+		t.Fatalf("EvalTerm(λx.x, int) has no error")
+	}
+	if code := exitCode(res); code != 1 {
+		t.Errorf("exitCode = %d for %v, want 1", code, res.Err)
+	}
+
+	// Running out of steps is not an error
+	expr, _ = lambda.Parse("(λx.x x) (λx.x x)")
+	res = lambda.EvalTerm(expr, "", 10)
+	if res.Complete || exitCode(res) != 0 {
+		t.Errorf("exitCode = %d for an incomplete result, want 0", exitCode(res))
+	}
+}
+
+func TestRunRender(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	out := filepath.Join(t.TempDir(), "church2.svg")
+	if code := run([]string{"lambdarun", "-render", "svg", "-o", out, `\f.\x.f (f x)`}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing when writing to a file", stdout.String())
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var svg struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &svg); err != nil || svg.XMLName.Local != "svg" {
+		t.Errorf("%s is not valid SVG (%v):\n%s", out, err, data)
+	}
+
+	// - is stdout
+	stdout.Reset()
+	if code := run([]string{"lambdarun", "-render", "text", "-o", "-", `\x.x`}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "┬\n╵\n" {
+		t.Errorf("stdout = %q, want the text diagram of I", stdout.String())
+	}
+}
+
+func TestRunRenderErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	out := filepath.Join(t.TempDir(), "missing", "out.svg")
+	if code := run([]string{"lambdarun", "-render", "svg", "-o", out, `\x.x`}, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code %d for an unwritable path, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "Error:") {
+		t.Errorf("stderr = %q, want an error", stderr.String())
+	}
+
+	if code := run([]string{"lambdarun", "-render", "png", `\x.x`}, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code %d for an unknown format, want 1", code)
+	}
+}
@@ -0,0 +1,37 @@
+package lambda
+
+// Clone returns a deep copy of t sharing no structure with it. Terms are
+// immutable, so this is only needed when a term is handed to code that
+// relies on its identity, such as a cache keyed by pointer, or to keep a
+// term independent of later changes to the representation.
+//
+// A LazyScript is copied as a new LazyScript with the same script, already
+// resolved to a clone of the original's term, so it shares neither the
+// parsed term nor the constants such as PRED the term refers to. As those
+// are copies, code recognizing the package constants by identity, like the
+// Reducer fast paths, does not recognize them in a clone.
+func Clone(t Term) Term {
+	switch t := t.(type) {
+	case Var:
+		return Var{Name: t.Name}
+	case Abstraction:
+		return Abstraction{Param: t.Param, Body: Clone(t.Body)}
+	case Application:
+		return Application{Func: Clone(t.Func), Arg: Clone(t.Arg)}
+	case *LazyScript:
+		c := MakeLazyScript(t.script)
+		c.once.Do(func() {
+			parsed, err := t.Resolve()
+			if err != nil {
+				c.err = err
+				return
+			}
+			c.parsed = Clone(parsed)
+		})
+		return c
+	case NumeralApply:
+		return NumeralApply{N: t.N, Param: t.Param, F: Clone(t.F)}
+	}
+	// Numeral is a plain value
+	return t
+}
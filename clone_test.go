@@ -0,0 +1,74 @@
+package lambda
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	terms := []Term{
+		Var{Name: "x"},
+		ChurchNumeral(2),
+		Application{Func: Abstraction{Param: "x", Body: Var{Name: "x"}}, Arg: Var{Name: "y"}},
+		Numeral(5),
+		NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}},
+	}
+	for _, term := range terms {
+		if c := Clone(term); !reflect.DeepEqual(c, term) {
+			t.Errorf("Clone(%s) = %#v, want %#v", term, c, term)
+		}
+	}
+
+	c := Clone(FACTORIAL)
+	ls, ok := c.(*LazyScript)
+	if !ok {
+		t.Fatalf("Clone(FACTORIAL) is %T, want *LazyScript", c)
+	}
+	if ls == FACTORIAL {
+		t.Error("Clone(FACTORIAL) returned the same LazyScript")
+	}
+	if !AlphaEquivalent(ls, FACTORIAL) {
+		t.Errorf("Clone(FACTORIAL) = %s, want %s", ls, FACTORIAL)
+	}
+
+	// No LazyScript, nested ones included, is shared with the original
+	original := make(map[*LazyScript]bool)
+	lazyScripts(FACTORIAL, original)
+	cloned := make(map[*LazyScript]bool)
+	lazyScripts(ls, cloned)
+	for l := range cloned {
+		if original[l] {
+			t.Errorf("Clone(FACTORIAL) shares the LazyScript %s", l)
+		}
+	}
+}
+
+// lazyScripts collects the LazyScripts reachable from t, inside the parsed
+// terms of LazyScripts too.
+func lazyScripts(t Term, seen map[*LazyScript]bool) {
+	switch t := t.(type) {
+	case *LazyScript:
+		if !seen[t] {
+			seen[t] = true
+			lazyScripts(t.parse(), seen)
+		}
+	case Abstraction:
+		lazyScripts(t.Body, seen)
+	case Application:
+		lazyScripts(t.Func, seen)
+		lazyScripts(t.Arg, seen)
+	case NumeralApply:
+		lazyScripts(t.F, seen)
+	}
+}
+
+func TestFreeVarsOwned(t *testing.T) {
+	// Modifying a returned map must not affect later calls
+	term := Abstraction{Param: "x", Body: Application{Func: Var{Name: "x"}, Arg: Var{Name: "y"}}}
+	fv := term.FreeVars()
+	fv["z"] = true
+	delete(fv, "y")
+	if fv := term.FreeVars(); len(fv) != 1 || !fv["y"] {
+		t.Errorf("FreeVars() = %v after modifying an earlier result, want {y}", fv)
+	}
+}
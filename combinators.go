@@ -140,6 +140,12 @@ var (
 	// EQ := λm.λn.AND (LEQ m n) (LEQ n m)
 	EQ = MakeLazyScript(`λm.λn._AND (_LEQ m n) (_LEQ n m)`)
 
+	// GT := λm.λn.NOT (LEQ m n)
+	GT = MakeLazyScript(`λm.λn._NOT (_LEQ m n)`)
+
+	// GEQ := λm.λn.LEQ n m
+	GEQ = MakeLazyScript(`λm.λn._LEQ n m`)
+
 	// MAX := λa.λb.IF (LEQ a b) b a
 	MAX = MakeLazyScript(`λa.λb._IF (_LEQ a b) b a`)
 
@@ -196,6 +202,15 @@ var (
 	ISEVEN = MakeLazyScript(`λn._NOT (_ISODD n)`)
 )
 
+// Conversions between booleans and numerals
+var (
+	// BOOLTONUM := λb.b 1 0 (TRUE → 1, FALSE → 0)
+	BOOLTONUM = MakeLazyScript(`λb.b _1 _0`)
+
+	// NUMTOBOOL := λn.NOT (ISZERO n) (0 → FALSE, anything else → TRUE)
+	NUMTOBOOL = MakeLazyScript(`λn._NOT (_ISZERO n)`)
+)
+
 // MUL := λm.λn.λf.m (n f)
 // Note: MUL is already defined above as MULT, but we need it for POWMOD
 var MUL = MULT
@@ -232,11 +247,11 @@ var (
 
 // List operations
 var (
-	// NIL := λx.TRUE
-	NIL = MakeLazyScript(`λx._TRUE`)
+	// NIL := λc.λn.n
+	NIL = MakeLazyScript(`λc.λn.n`)
 
-	// NULL := λp.p (λx.λy.FALSE)
-	NULL = MakeLazyScript(`λp.p (λx.λy._FALSE)`)
+	// NULL := λl.l (λh.λt.FALSE) TRUE
+	NULL = MakeLazyScript(`λl.l (λh.λt._FALSE) _TRUE`)
 )
 
 // Y combinator for recursion
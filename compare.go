@@ -0,0 +1,37 @@
+package lambda
+
+import (
+	"cmp"
+)
+
+// Three-way comparison
+//
+// CMP compares two numerals in one reduction and returns a numeral tag,
+// so a program can branch three ways without chaining LT, EQ and GT. It
+// reduces SUB m n, and SUB n m only when the first difference is zero, to
+// tell equal numerals from a smaller m. SUB takes a number of steps
+// quadratic in its arguments.
+var (
+	// CMP := λm.λn.(λd.ISZERO d (ISZERO (SUB n m) 1 0) 2) (SUB m n)
+	// 0 if m < n, 1 if m = n, 2 if m > n
+	CMP = MakeLazyScript(`λm.λn.(λd._ISZERO d (_ISZERO (_SUB n m) _1 _0) _2) (_SUB m n)`)
+)
+
+// Compare compares the Church numerals a and b, returning -1 if a < b, 0 if
+// they are equal and +1 if a > b, like cmp.Compare. If both are already
+// numerals in normal form they are compared directly; otherwise CMP a b is
+// reduced for at most limit steps, or DefaultStepLimit if limit is 0 or
+// negative. It reports false if that does not reduce to a tag, for example
+// when a or b is not a numeral or the limit is too low.
+func Compare(a, b Term, limit int) (int, bool) {
+	if m, ok := AsInt(a); ok {
+		if n, ok := AsInt(b); ok {
+			return cmp.Compare(m, n), true
+		}
+	}
+	tag, ok := ToIntPure(Apply(CMP, a, b), limit)
+	if !ok || tag > 2 {
+		return 0, false
+	}
+	return tag - 1, true
+}
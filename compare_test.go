@@ -0,0 +1,84 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestCMP(t *testing.T) {
+	tests := []struct {
+		m, n int
+		tag  int
+	}{
+		{0, 0, 1},
+		{2, 2, 1},
+		{1, 3, 0},
+		{0, 2, 0},
+		{3, 1, 2},
+		{2, 0, 2},
+	}
+
+	for _, tt := range tests {
+		got, ok := ToIntPure(Apply(CMP, ChurchNumeral(tt.m), ChurchNumeral(tt.n)), 5000)
+		if !ok || got != tt.tag {
+			t.Errorf("CMP %d %d = %d, %v, want %d", tt.m, tt.n, got, ok, tt.tag)
+		}
+		if got, ok := Compare(ChurchNumeral(tt.m), ChurchNumeral(tt.n), 0); !ok || got != tt.tag-1 {
+			t.Errorf("Compare(%d, %d) = %d, %v, want %d", tt.m, tt.n, got, ok, tt.tag-1)
+		}
+	}
+}
+
+func TestCompareLarger(t *testing.T) {
+	tests := []struct {
+		m, n int
+		want int
+	}{
+		{20, 19, 1},
+		{9, 10, -1},
+		{25, 30, -1},
+		{30, 29, 1},
+		{17, 17, 0},
+	}
+
+	for _, tt := range tests {
+		m, n := ChurchNumeral(tt.m), ChurchNumeral(tt.n)
+		if got, ok := Compare(m, n, 0); !ok || got != tt.want {
+			t.Errorf("Compare(%d, %d) = %d, %v, want %d", tt.m, tt.n, got, ok, tt.want)
+		}
+	}
+
+	// Unreduced arguments go through CMP, given enough steps
+	if got, ok := Compare(Apply(SUCC, ChurchNumeral(11)), ChurchNumeral(10), 20000); !ok || got != 1 {
+		t.Errorf("Compare(SUCC 11, 10) = %d, %v, want 1", got, ok)
+	}
+
+	// Running out of steps is not equality
+	if got, ok := Compare(Apply(SUCC, ChurchNumeral(20)), ChurchNumeral(19), 10); ok {
+		t.Errorf("Compare(SUCC 20, 19) in 10 steps = %d, want a failure", got)
+	}
+	if got, ok := Compare(Var{Name: "x"}, ChurchNumeral(1), 0); ok {
+		t.Errorf("Compare(x, 1) = %d, want a failure", got)
+	}
+}
+
+func TestGTGEQ(t *testing.T) {
+	tests := []struct {
+		m, n    int
+		gt, geq bool
+	}{
+		{1, 2, false, false},
+		{2, 2, false, true},
+		{3, 2, true, true},
+		{0, 0, false, true},
+	}
+
+	for _, tt := range tests {
+		m, n := ChurchNumeral(tt.m), ChurchNumeral(tt.n)
+		if got := ToBool(Apply(GT, m, n)); got != tt.gt {
+			t.Errorf("GT %d %d = %v, want %v", tt.m, tt.n, got, tt.gt)
+		}
+		if got := ToBool(Apply(GEQ, m, n)); got != tt.geq {
+			t.Errorf("GEQ %d %d = %v, want %v", tt.m, tt.n, got, tt.geq)
+		}
+	}
+}
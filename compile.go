@@ -0,0 +1,128 @@
+package lambda
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Surface language
+//
+// Compile translates a small ML-like language into lambda terms built from
+// the package combinators. It is the syntax of a Parser with the Infix and
+// Keywords options, whose operators, if and application it shares, with
+// two more forms:
+//
+//	42                          ChurchNumeral(42)
+//	true, false                 TRUE, FALSE
+//	a + b, a - b, a * b         PLUS a b, SUB a b, MULT a b
+//	a == b, a < b, a <= b, ...  EQ a b, LT a b, LEQ a b, ...
+//	if c then a else b          IF c a b
+//	fun x y -> e                λx.λy.e
+//	let x = a in b              (λx.b) a
+//	let f x = a in b            (λf.b) (λx.a)
+//	let rec f x = a in b        (λf.b) (Y (λf.λx.a))
+//	f a b                       application, binding tighter than operators
+//
+// Subtraction is truncated at zero, like SUB. Names with a leading
+// underscore refer to the parser constants, such as _FACTORIAL or _7.
+
+// compileMaxDepth bounds the nesting of compiled programs, and so their
+// number literals, which are Church numerals of depth n+3
+const compileMaxDepth = 10000
+
+// Compile parses a program of the surface language and returns the lambda
+// term it denotes. Names must be bound by fun or let; an unbound name is an
+// error. Programs nested deeper than 10000, or with a number literal that
+// large, are rejected with an error wrapping ErrTooDeep.
+func Compile(src string) (Term, error) {
+	p := &Parser{Infix: true, Keywords: true, MaxDepth: compileMaxDepth, surface: true}
+	term, err := p.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if free := FreeVarNames(term); len(free) > 0 {
+		return nil, fmt.Errorf("unbound name %s", strings.Join(free, ", "))
+	}
+	return term, nil
+}
+
+// parseFun parses the rest of fun params -> body, after the fun
+func (p *Parser) parseFun() (Term, error) {
+	params := p.parseParams()
+	if len(params) == 0 {
+		return nil, fmt.Errorf("expected a parameter at position %d", p.pos)
+	}
+	p.skipWhitespace()
+	if !strings.HasPrefix(p.input[p.pos:], "->") {
+		return nil, fmt.Errorf("expected \"->\" at position %d", p.pos)
+	}
+	p.pos += len("->")
+	body, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return lambdas(params, body), nil
+}
+
+// parseLet parses the rest of let [rec] name params = value in body, after
+// the let
+func (p *Parser) parseLet() (Term, error) {
+	p.skipWhitespace()
+	start := p.pos
+	rec := p.parseIdentifier() == "rec"
+	if !rec {
+		p.pos = start
+	}
+	names := p.parseParams()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("expected a name at position %d", p.pos)
+	}
+	name, params := names[0], names[1:]
+
+	p.skipWhitespace()
+	if p.peek() != '=' || strings.HasPrefix(p.input[p.pos:], "==") {
+		return nil, fmt.Errorf("expected '=' at position %d", p.pos)
+	}
+	p.pos++
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("in"); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	value = lambdas(params, value)
+	if rec {
+		value = Application{Func: Y, Arg: Abstraction{Param: name, Body: value}}
+	}
+	return Application{Func: Abstraction{Param: name, Body: body}, Arg: value}, nil
+}
+
+// parseParams parses the names before = or ->, which are neither keywords
+// nor constants
+func (p *Parser) parseParams() []string {
+	var names []string
+	for {
+		p.skipWhitespace()
+		start := p.pos
+		name := p.parseIdentifier()
+		if name == "" || p.isKeyword(name) || name[0] == '_' {
+			p.pos = start
+			return names
+		}
+		names = append(names, name)
+	}
+}
+
+// lambdas wraps body in one abstraction per parameter
+func lambdas(params []string, body Term) Term {
+	for i := len(params) - 1; i >= 0; i-- {
+		body = Abstraction{Param: params[i], Body: body}
+	}
+	return body
+}
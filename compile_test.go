@@ -0,0 +1,81 @@
+package lambda
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		src  string
+		want int
+	}{
+		{"let sq = fun x -> x*x in sq 3", 9},
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"10 - 3 - 2", 5},
+		{"2 - 5", 0},
+		{"if 2 < 3 then 1 else 0", 1},
+		{"if 3 <= 2 then 1 else 0", 0},
+		{"if true then 7 else 8", 7},
+		{"let add x y = x + y in add 2 (add 1 1)", 4},
+		{"(fun f x -> f (f x)) (fun n -> n + 3) 1", 7},
+		{"let rec fact n = if n == 0 then 1 else n * fact (n - 1) in fact 3", 6},
+		{"_FACTORIAL _3 + 1", 7},
+	}
+
+	for _, tt := range tests {
+		term, err := Compile(tt.src)
+		if err != nil {
+			t.Errorf("Compile(%q) error: %v", tt.src, err)
+			continue
+		}
+		if got, ok := ToIntPure(term, 100000); !ok || got != tt.want {
+			t.Errorf("Compile(%q) = %d, %v, want %d", tt.src, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCompileLargeLiteral(t *testing.T) {
+	start := time.Now()
+	if _, err := Compile("1000000000"); !errors.Is(err, ErrTooDeep) {
+		t.Errorf("Compile(\"1000000000\") error = %v, want ErrTooDeep", err)
+	}
+	if _, err := Compile("_N1000000000"); !errors.Is(err, ErrTooDeep) {
+		t.Errorf("Compile(\"_N1000000000\") error = %v, want ErrTooDeep", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("rejecting large literals took %v", d)
+	}
+
+	term, err := Compile("1000 + 1")
+	if err != nil {
+		t.Fatalf("Compile(\"1000 + 1\") error: %v", err)
+	}
+	if n, ok := ToIntPure(term, 0); !ok || n != 1001 {
+		t.Errorf("Compile(\"1000 + 1\") = %d, %v, want 1001", n, ok)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"x + 1",
+		"let in = 1 in 2",
+		"let x = 1",
+		"if 1 then 2",
+		"fun -> 1",
+		"(1 + 2",
+		"1 +",
+		"1 ? 2",
+		"_NOSUCH",
+		"let rec = 1 in 2",
+		"fun x = 1",
+		"in",
+	} {
+		if _, err := Compile(src); err == nil {
+			t.Errorf("Compile(%q) succeeded, want an error", src)
+		}
+	}
+}
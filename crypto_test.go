@@ -113,16 +113,7 @@ func TestPOWMOD(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			result := Application{
-				Func: Application{
-					Func: Application{
-						Func: POWMOD,
-						Arg:  ChurchNumeral(tt.a),
-					},
-					Arg: ChurchNumeral(tt.e),
-				},
-				Arg: ChurchNumeral(tt.m),
-			}
+			result := Apply(POWMOD, ChurchNumeral(tt.a), ChurchNumeral(tt.e), ChurchNumeral(tt.m))
 
 			reduced, steps := Reduce(result, 20000)
 			got := ToInt(reduced)
@@ -150,19 +141,7 @@ func TestPOWMOD_PRIME(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			// POWMOD_PRIME takes 4 args: a, e, m, r (where r is the accumulator, initially 1)
-			result := Application{
-				Func: Application{
-					Func: Application{
-						Func: Application{
-							Func: POWMOD_PRIME,
-							Arg:  ChurchNumeral(tt.a),
-						},
-						Arg: ChurchNumeral(tt.e),
-					},
-					Arg: ChurchNumeral(tt.m),
-				},
-				Arg: ONE, // Initial accumulator
-			}
+			result := Apply(POWMOD_PRIME, ChurchNumeral(tt.a), ChurchNumeral(tt.e), ChurchNumeral(tt.m), ONE)
 
 			reduced, steps := Reduce(result, 20000)
 			got := ToInt(reduced)
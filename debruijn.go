@@ -0,0 +1,178 @@
+package lambda
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// De Bruijn notation
+//
+// A nameless term replaces each bound variable by a number identifying its
+// binder. The numbering comes in two flavours:
+//   - indices count binders outwards from the use site: the nearest
+//     enclosing λ is 0, so K = λx.λy.x is λλ1;
+//   - levels count binders inwards from the root: the outermost λ is 0, so
+//     K is λλ0.
+//
+// The same variable gets the same level everywhere within its scope, while
+// its index changes with the depth of each occurrence. Both are written
+// using the same DeBruijnTerm values; which flavour a term uses depends on
+// the function that built it.
+
+// DeBruijnTerm is a term in de Bruijn notation.
+type DeBruijnTerm interface {
+	String() string
+	deBruijn()
+}
+
+// DBVar is a variable. Bound variables have an empty Name and are
+// identified by N; free variables keep their Name.
+type DBVar struct {
+	N    int
+	Name string
+}
+
+// DBAbs is an abstraction.
+type DBAbs struct {
+	Body DeBruijnTerm
+}
+
+// DBApp is an application.
+type DBApp struct {
+	Func DeBruijnTerm
+	Arg  DeBruijnTerm
+}
+
+func (DBVar) deBruijn() {}
+func (DBAbs) deBruijn() {}
+func (DBApp) deBruijn() {}
+
+func (v DBVar) String() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return strconv.Itoa(v.N)
+}
+
+func (a DBAbs) String() string {
+	return "λ" + a.Body.String()
+}
+
+func (a DBApp) String() string {
+	funcStr := a.Func.String()
+	if _, isAbs := a.Func.(DBAbs); isAbs {
+		funcStr = "(" + funcStr + ")"
+	}
+	argStr := a.Arg.String()
+	switch a.Arg.(type) {
+	case DBAbs, DBApp:
+		argStr = "(" + argStr + ")"
+	}
+	return funcStr + " " + argStr
+}
+
+// ToDeBruijnIndices converts t to de Bruijn indices.
+func ToDeBruijnIndices(t Term) DeBruijnTerm {
+	return fromDiagramTerm(toDeBruijn(t, nil), 0, true)
+}
+
+// ToDeBruijnLevels converts t to de Bruijn levels.
+func ToDeBruijnLevels(t Term) DeBruijnTerm {
+	return fromDiagramTerm(toDeBruijn(t, nil), 0, false)
+}
+
+// fromDiagramTerm converts the nameless form the diagrams are drawn from,
+// which numbers free variables past the binders in scope, depth being the
+// number of those binders.
+func fromDiagramTerm(t dbTerm, depth int, indices bool) DeBruijnTerm {
+	switch t := t.(type) {
+	case dbVar:
+		if t.index >= depth {
+			return DBVar{Name: t.name}
+		}
+		if indices {
+			return DBVar{N: t.index}
+		}
+		return DBVar{N: depth - 1 - t.index}
+	case dbAbs:
+		return DBAbs{Body: fromDiagramTerm(t.body, depth+1, indices)}
+	case dbApp:
+		return DBApp{Func: fromDiagramTerm(t.fun, depth, indices), Arg: fromDiagramTerm(t.arg, depth, indices)}
+	}
+	panic(fmt.Sprintf("unknown term type %T", t))
+}
+
+// FromDeBruijnIndices converts a term in de Bruijn indices back to a named
+// term, naming binders x0, x1, ... by depth while avoiding free variables.
+// It fails if an index does not refer to an enclosing λ.
+func FromDeBruijnIndices(d DeBruijnTerm) (Term, error) {
+	return fromNameless(d, nil, dbFreeNames(d, nil), true)
+}
+
+// FromDeBruijnLevels converts a term in de Bruijn levels back to a named
+// term, naming binders x0, x1, ... by depth while avoiding free variables.
+// It fails if a level does not refer to an enclosing λ.
+func FromDeBruijnLevels(d DeBruijnTerm) (Term, error) {
+	return fromNameless(d, nil, dbFreeNames(d, nil), false)
+}
+
+func fromNameless(d DeBruijnTerm, env []string, free map[string]bool, indices bool) (Term, error) {
+	switch d := d.(type) {
+	case DBVar:
+		if d.Name != "" {
+			return Var{Name: d.Name}, nil
+		}
+		level := d.N
+		if indices {
+			level = len(env) - 1 - d.N
+		}
+		if level < 0 || level >= len(env) {
+			return nil, fmt.Errorf("de Bruijn variable %d out of scope at depth %d", d.N, len(env))
+		}
+		return Var{Name: env[level]}, nil
+	case DBAbs:
+		avoid := make(map[string]bool, len(free)+len(env))
+		for name := range free {
+			avoid[name] = true
+		}
+		for _, name := range env {
+			avoid[name] = true
+		}
+		name := freshVar(fmt.Sprintf("x%d", len(env)), avoid)
+		body, err := fromNameless(d.Body, append(env, name), free, indices)
+		if err != nil {
+			return nil, err
+		}
+		return Abstraction{Param: name, Body: body}, nil
+	case DBApp:
+		f, err := fromNameless(d.Func, env, free, indices)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := fromNameless(d.Arg, env, free, indices)
+		if err != nil {
+			return nil, err
+		}
+		return Application{Func: f, Arg: arg}, nil
+	}
+	return nil, fmt.Errorf("unknown de Bruijn term type %T", d)
+}
+
+// dbFreeNames collects the names of the free variables of d.
+func dbFreeNames(d DeBruijnTerm, names map[string]bool) map[string]bool {
+	if names == nil {
+		names = make(map[string]bool)
+	}
+	switch d := d.(type) {
+	case DBVar:
+		if d.Name != "" {
+			names[d.Name] = true
+		}
+	case DBAbs:
+		dbFreeNames(d.Body, names)
+	case DBApp:
+		dbFreeNames(d.Func, names)
+		dbFreeNames(d.Arg, names)
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestDeBruijn(t *testing.T) {
+	tests := []struct {
+		input   string
+		indices string
+		levels  string
+	}{
+		{"λx.x", "λ0", "λ0"},
+		{"λx.λy.x", "λλ1", "λλ0"},
+		{"λx.λy.y", "λλ0", "λλ1"},
+		{"λx.λy.λz.x z (y z)", "λλλ2 0 (1 0)", "λλλ0 2 (1 2)"},
+		{"λf.λx.f (f x)", "λλ1 (1 0)", "λλ0 (0 1)"},
+		{"λx.x (λy.x y)", "λ0 (λ1 0)", "λ0 (λ0 1)"},
+		{"λx.y x", "λy 0", "λy 0"},
+		{"(λx.x) z", "(λ0) z", "(λ0) z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			term, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+
+			indices := ToDeBruijnIndices(term)
+			if indices.String() != tt.indices {
+				t.Errorf("ToDeBruijnIndices = %s, want %s", indices, tt.indices)
+			}
+			levels := ToDeBruijnLevels(term)
+			if levels.String() != tt.levels {
+				t.Errorf("ToDeBruijnLevels = %s, want %s", levels, tt.levels)
+			}
+
+			if back, err := FromDeBruijnIndices(indices); err != nil || !AlphaEquivalent(back, term) {
+				t.Errorf("FromDeBruijnIndices = %s, %v, want %s", back, err, term)
+			}
+			if back, err := FromDeBruijnLevels(levels); err != nil || !AlphaEquivalent(back, term) {
+				t.Errorf("FromDeBruijnLevels = %s, %v, want %s", back, err, term)
+			}
+		})
+	}
+}
+
+func TestDeBruijnLevelsK(t *testing.T) {
+	levels := ToDeBruijnLevels(K)
+	want := DBAbs{Body: DBAbs{Body: DBVar{N: 0}}}
+	if levels != DeBruijnTerm(want) {
+		t.Errorf("ToDeBruijnLevels(K) = %#v, want %#v", levels, want)
+	}
+
+	back, err := FromDeBruijnLevels(levels)
+	if err != nil {
+		t.Fatalf("FromDeBruijnLevels error: %v", err)
+	}
+	if back.String() != "λx0.λx1.x0" {
+		t.Errorf("FromDeBruijnLevels = %s, want λx0.λx1.x0", back)
+	}
+	if !AlphaEquivalent(back, K) {
+		t.Errorf("round trip of K = %s is not α-equivalent to K", back)
+	}
+}
+
+func TestFromDeBruijnAvoidsFreeNames(t *testing.T) {
+	// λ.x0 0 with x0 free must not capture it
+	d := DBAbs{Body: DBApp{Func: DBVar{Name: "x0"}, Arg: DBVar{N: 0}}}
+	term, err := FromDeBruijnIndices(d)
+	if err != nil {
+		t.Fatalf("FromDeBruijnIndices error: %v", err)
+	}
+	if fv := term.FreeVars(); !fv["x0"] {
+		t.Errorf("%s should keep x0 free", term)
+	}
+}
+
+func TestFromDeBruijnOutOfScope(t *testing.T) {
+	for _, d := range []DeBruijnTerm{
+		DBVar{N: 0},
+		DBAbs{Body: DBVar{N: 1}},
+		DBAbs{Body: DBApp{Func: DBVar{N: 0}, Arg: DBVar{N: -1}}},
+	} {
+		if term, err := FromDeBruijnIndices(d); err == nil {
+			t.Errorf("FromDeBruijnIndices(%s) = %s, want an error", d, term)
+		}
+		if term, err := FromDeBruijnLevels(d); err == nil {
+			t.Errorf("FromDeBruijnLevels(%s) = %s, want an error", d, term)
+		}
+	}
+}
@@ -16,10 +16,29 @@ func Diagram(term Term) string {
 	return grid.String()
 }
 
-// De Bruijn representation
+// DiagramASCII is like Diagram, but draws with the ASCII characters '|',
+// '-' and '+' only, for terminals, fonts and logs that do not render
+// box-drawing characters. The layout is the same, one character per cell.
+func DiagramASCII(term Term) string {
+	db := toDeBruijn(term, nil)
+	info := computeInfo(db)
+	grid := newGrid(info.width, info.height)
+
+	drawTerm(db, 0, 0, nil, grid)
+	return grid.ASCII()
+}
+
+// De Bruijn representation, keeping the names for display. It is also the
+// source of the exported DeBruijnTerm conversions.
 type dbTerm interface{ dbTag() }
-type dbVar struct{ index int }
-type dbAbs struct{ body dbTerm }
+type dbVar struct {
+	index int
+	name  string // the original name, for display only
+}
+type dbAbs struct {
+	body  dbTerm
+	param string // the original parameter name, for display only
+}
 type dbApp struct{ fun, arg dbTerm }
 
 func (dbVar) dbTag() {}
@@ -34,23 +53,18 @@ func toDeBruijn(t Term, env []string) dbTerm {
 		t = num.Expand()
 	}
 	if na, ok := t.(NumeralApply); ok {
-		// Expand to λparam.F^N(param)
-		var body Term = Var{Name: na.Param}
-		for i := uint64(0); i < na.N; i++ {
-			body = Application{Func: na.F, Arg: body}
-		}
-		t = Abstraction{Param: na.Param, Body: body}
+		t = na.Expand()
 	}
 	switch term := t.(type) {
 	case Var:
 		for i := len(env) - 1; i >= 0; i-- {
 			if env[i] == term.Name {
-				return dbVar{index: len(env) - 1 - i}
+				return dbVar{index: len(env) - 1 - i, name: term.Name}
 			}
 		}
-		return dbVar{index: len(env)}
+		return dbVar{index: len(env), name: term.Name}
 	case Abstraction:
-		return dbAbs{body: toDeBruijn(term.Body, append(env, term.Param))}
+		return dbAbs{body: toDeBruijn(term.Body, append(env, term.Param)), param: term.Param}
 	case Application:
 		return dbApp{
 			fun: toDeBruijn(term.Func, env),
@@ -151,6 +165,17 @@ func (g *grid) vLine(col, r1, r2 int) {
 }
 
 func (g *grid) String() string {
+	return g.render(boxChar)
+}
+
+// ASCII renders the grid like String, with asciiChar instead of boxChar.
+func (g *grid) ASCII() string {
+	return g.render(asciiChar)
+}
+
+// render draws each cell with the character char picks for the lines
+// leaving it.
+func (g *grid) render(char func(up, down, left, right bool) rune) string {
 	var sb strings.Builder
 	for r := 0; r < g.h; r++ {
 		if r > 0 {
@@ -158,7 +183,7 @@ func (g *grid) String() string {
 		}
 		line := make([]rune, g.w)
 		for c := 0; c < g.w; c++ {
-			line[c] = boxChar(g.up[r][c], g.down[r][c], g.left[r][c], g.right[r][c])
+			line[c] = char(g.up[r][c], g.down[r][c], g.left[r][c], g.right[r][c])
 		}
 		sb.WriteString(strings.TrimRight(string(line), " "))
 	}
@@ -202,6 +227,23 @@ func boxChar(up, down, left, right bool) rune {
 	}
 }
 
+// asciiChar is boxChar for terminals without box-drawing characters: '|'
+// for vertical lines, '-' for horizontal ones and '+' where they meet.
+func asciiChar(up, down, left, right bool) rune {
+	vertical := up || down
+	horizontal := left || right
+	switch {
+	case vertical && horizontal:
+		return '+'
+	case vertical:
+		return '|'
+	case horizontal:
+		return '-'
+	default:
+		return ' '
+	}
+}
+
 // drawTerm recursively draws the term onto the grid.
 // topRow/leftCol: absolute position of this term's top-left corner.
 // lambdaRows: maps de Bruijn indices to absolute rows of binding lambda bars.
@@ -258,3 +300,178 @@ func drawTerm(t dbTerm, topRow, leftCol int, lambdaRows []int, g *grid) (outRow,
 	}
 	return topRow, leftCol
 }
+
+// DiagramVertical is like Diagram, but stacks the arguments of an
+// application spine f a b c on top of each other to the right of f instead
+// of placing them side by side, trading width for height so that large
+// terms fit in portrait space. Each argument is linked to the wire of f by
+// its own connector bar, the first argument's bar being the highest. An
+// argument using variables bound outside it is shifted right just enough
+// for their wires to run up beside the arguments stacked above it.
+func DiagramVertical(term Term) string {
+	db := toDeBruijn(term, nil)
+	layout := layoutVertical(db, 0)
+	grid := newGrid(layout.info.width, layout.info.height)
+
+	drawVertical(db, layout, 0, 0, nil, grid)
+	return grid.String()
+}
+
+// spine splits an application into its head and arguments, so that
+// f a b c gives f and [a b c].
+func spine(t dbTerm) (dbTerm, []dbTerm) {
+	var args []dbTerm
+	for {
+		app, ok := t.(dbApp)
+		if !ok {
+			break
+		}
+		args = append(args, app.arg)
+		t = app.fun
+	}
+	for i, j := 0, len(args)-1; i < j; i, j = i+1, j-1 {
+		args[i], args[j] = args[j], args[i]
+	}
+	return t, args
+}
+
+// vLayout is the vertical layout of a term, computed once for each node
+// and reused by drawVertical.
+type vLayout struct {
+	info    termInfo
+	body    *vLayout   // for an abstraction
+	head    *vLayout   // for an application spine
+	args    []*vLayout // for an application spine
+	offsets []int      // column of each argument relative to the first one
+	// wires[l] is the leftmost column of a wire rising out of the term to
+	// the lambda at level l around it, the outermost being 0, or -1 if the
+	// term does not use that lambda
+	wires []int
+}
+
+// layoutVertical computes the vertical layout of t bottom-up, bound being
+// the number of lambdas around t.
+func layoutVertical(t dbTerm, bound int) *vLayout {
+	switch term := t.(type) {
+	case dbVar:
+		l := &vLayout{info: termInfo{width: 1, height: 1, rootCol: 0}, wires: noWires(bound)}
+		if term.index < bound {
+			l.wires[bound-1-term.index] = 0
+		}
+		return l
+	case dbAbs:
+		body := layoutVertical(term.body, bound+1)
+		return &vLayout{
+			info:  termInfo{width: body.info.width, height: 1 + body.info.height, rootCol: body.info.rootCol},
+			body:  body,
+			wires: body.wires[:bound],
+		}
+	case dbApp:
+		head, args := spine(term)
+		l := &vLayout{head: layoutVertical(head, bound), wires: noWires(bound)}
+		copy(l.wires, l.head.wires)
+		h := l.head.info
+		argCol := h.width + 1
+
+		// The wires of the variables an argument uses from outside must not
+		// meet the arguments above it, nor their connector bars, which end
+		// within them: an argument is shifted so that all these wires lie
+		// right of the arguments above, with a blank column in between.
+		l.args = make([]*vLayout, len(args))
+		l.offsets = make([]int, len(args))
+		argWidth := 0
+		connRow := -1
+		for i, arg := range args {
+			a := layoutVertical(arg, bound)
+			l.args[i] = a
+			if col, ok := leftmostWire(a.wires); ok && i > 0 && col < argWidth+1 {
+				l.offsets[i] = argWidth + 1 - col
+			}
+			argWidth = max(argWidth, l.offsets[i]+a.info.width)
+			for level, col := range a.wires {
+				if col >= 0 {
+					col += argCol + l.offsets[i]
+					if l.wires[level] < 0 || col < l.wires[level] {
+						l.wires[level] = col
+					}
+				}
+			}
+
+			// The argument starts below the previous connector bar, beside
+			// the head for the first one, and gets its own bar below it
+			connRow = max(connRow+1+a.info.height, h.height)
+		}
+		l.info = termInfo{
+			width:   h.width + 1 + argWidth,
+			height:  connRow + 1,
+			rootCol: h.rootCol,
+		}
+		return l
+	}
+	return &vLayout{wires: noWires(bound)}
+}
+
+// noWires returns the wires of a term using none of the bound lambdas
+// around it.
+func noWires(bound int) []int {
+	wires := make([]int, bound)
+	for i := range wires {
+		wires[i] = -1
+	}
+	return wires
+}
+
+// leftmostWire returns the leftmost column among wires, reporting false if
+// there is none.
+func leftmostWire(wires []int) (int, bool) {
+	min, found := 0, false
+	for _, col := range wires {
+		if col >= 0 && (!found || col < min) {
+			min, found = col, true
+		}
+	}
+	return min, found
+}
+
+// drawVertical is drawTerm for the vertical layout of t.
+func drawVertical(t dbTerm, l *vLayout, topRow, leftCol int, lambdaRows []int, g *grid) (outRow, outCol int) {
+	switch term := t.(type) {
+	case dbVar:
+		return drawTerm(term, topRow, leftCol, lambdaRows, g)
+
+	case dbAbs:
+		g.hLine(topRow, leftCol, leftCol+l.body.info.width-1)
+		newStack := append(lambdaRows, topRow)
+		return drawVertical(term.body, l.body, topRow+1, leftCol, newStack, g)
+
+	case dbApp:
+		head, args := spine(term)
+		hInfo := l.head.info
+		headOutRow, headOutCol := drawVertical(head, l.head, topRow, leftCol, lambdaRows, g)
+
+		// Arguments are stacked in a column to the right of the head
+		argCol := leftCol + hInfo.width + 1
+		connRow := topRow - 1
+		for i, arg := range args {
+			a := l.args[i]
+			argTop := connRow + 1
+			argOutRow, argOutCol := drawVertical(arg, a, argTop, argCol+l.offsets[i], lambdaRows, g)
+
+			connRow = argTop + a.info.height
+			if connRow < topRow+hInfo.height {
+				connRow = topRow + hInfo.height
+			}
+			g.hLine(connRow, headOutCol, argOutCol)
+			if argOutRow < connRow {
+				g.vLine(argOutCol, argOutRow, connRow)
+			}
+		}
+
+		// The head's wire runs down past every connector bar
+		if headOutRow < connRow {
+			g.vLine(headOutCol, headOutRow, connRow)
+		}
+		return connRow, headOutCol
+	}
+	return topRow, leftCol
+}
@@ -0,0 +1,41 @@
+package lambda
+
+import (
+	"sync"
+)
+
+// DiagramCache renders diagrams once per term, up to α-equivalence. It is
+// safe for concurrent use.
+type DiagramCache struct {
+	opts     *SVGOptions
+	mu       sync.Mutex
+	diagrams map[string]*SVGDiagram
+}
+
+// NewDiagramCache creates a cache rendering diagrams with opts.
+func NewDiagramCache(opts *SVGOptions) *DiagramCache {
+	return &DiagramCache{opts: opts, diagrams: make(map[string]*SVGDiagram)}
+}
+
+// GetOrRender returns the diagram for t, rendering it only if no
+// α-equivalent term was rendered before. The returned diagram is shared
+// between callers and must not be modified.
+func (c *DiagramCache) GetOrRender(t Term) *SVGDiagram {
+	key := Fingerprint(t)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d, ok := c.diagrams[key]; ok {
+		return d
+	}
+	d := BuildSVGDiagram(t, c.opts)
+	c.diagrams[key] = d
+	return d
+}
+
+// Len returns the number of diagrams in the cache.
+func (c *DiagramCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.diagrams)
+}
@@ -0,0 +1,28 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestDiagramCache(t *testing.T) {
+	c := NewDiagramCache(nil)
+
+	a := c.GetOrRender(Abstraction{Param: "x", Body: Var{Name: "x"}})
+	b := c.GetOrRender(Abstraction{Param: "y", Body: Var{Name: "y"}})
+	if a != b {
+		t.Error("α-equivalent terms should share a cached diagram")
+	}
+
+	k := c.GetOrRender(K)
+	if k == a {
+		t.Error("different terms should not share a diagram")
+	}
+	if c.Len() != 2 {
+		t.Errorf("cache has %d diagrams, want 2", c.Len())
+	}
+
+	want := BuildSVGDiagram(K, nil)
+	if k.SVG(nil) != want.SVG(nil) {
+		t.Error("cached diagram differs from a fresh rendering")
+	}
+}
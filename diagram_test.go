@@ -1,7 +1,10 @@
 package lambda
 
 import (
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestDiagramIdentity(t *testing.T) {
@@ -136,3 +139,146 @@ func splitLines(s string) []string {
 	}
 	return lines
 }
+
+// diagramSize returns the number of rows and the width of the widest row
+// of a text diagram.
+func diagramSize(d string) (height, width int) {
+	lines := strings.Split(d, "\n")
+	for _, line := range lines {
+		if w := utf8.RuneCountInString(line); w > width {
+			width = w
+		}
+	}
+	return len(lines), width
+}
+
+func TestDiagramVertical(t *testing.T) {
+	term, err := Parse("f a b c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := DiagramVertical(term)
+	expect := "╷ ╷\n├─┘\n│ ╷\n├─┘\n│ ╷\n└─┘"
+	if got != expect {
+		t.Errorf("DiagramVertical(f a b c):\ngot:\n%s\nexpect:\n%s", got, expect)
+	}
+
+	hh, hw := diagramSize(Diagram(term))
+	vh, vw := diagramSize(got)
+	if vh <= hh || vw >= hw {
+		t.Errorf("vertical layout is %dx%d, horizontal %dx%d: expected taller and narrower", vw, vh, hw, hh)
+	}
+}
+
+func TestDiagramVerticalBoundWires(t *testing.T) {
+	// Arguments using the outer variables are shifted right so that their
+	// wires pass beside the arguments above instead of through them
+	tests := []struct {
+		term   string
+		expect string
+	}{
+		{"λf.λa.λb.f (a b) b a", "" +
+			"┌───────╴\n" +
+			"├─┬─────┐\n" +
+			"├─┼─┬─┬─┤\n" +
+			"│ │ │ │ │\n" +
+			"│ ├─┘ │ │\n" +
+			"├─┘   │ │\n" +
+			"│     │ │\n" +
+			"├─────┘ │\n" +
+			"│       │\n" +
+			"└───────┘"},
+		{"λx.λy.x (y x) (x y)", "" +
+			"┌───┬─┬─╴\n" +
+			"├─┬─┼─┼─┐\n" +
+			"│ │ │ │ │\n" +
+			"│ ├─┘ │ │\n" +
+			"├─┘   │ │\n" +
+			"│     │ │\n" +
+			"│     ├─┘\n" +
+			"└─────┘"},
+		// Closed arguments still stack in one column
+		{"λx.x (λy.y) (λy.y) (λy.y x)", "" +
+			"┌───┐\n" +
+			"│ ┬ │\n" +
+			"│ │ │\n" +
+			"├─┘ │\n" +
+			"│ ┬ │\n" +
+			"│ │ │\n" +
+			"├─┘ │\n" +
+			"│ ┌─┤\n" +
+			"│ │ │\n" +
+			"│ ├─┘\n" +
+			"└─┘"},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.term)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := DiagramVertical(term); got != tt.expect {
+			t.Errorf("DiagramVertical(%s):\ngot:\n%s\nexpect:\n%s", tt.term, got, tt.expect)
+		}
+	}
+}
+
+func TestDiagramVerticalSameWithoutSpines(t *testing.T) {
+	// Without an application of several arguments both layouts agree
+	for _, term := range []Term{I, K, U, OMEGA, ChurchNumeral(2), Y} {
+		if h, v := Diagram(term), DiagramVertical(term); h != v {
+			t.Errorf("layouts differ for %s:\nhorizontal:\n%s\nvertical:\n%s", term, h, v)
+		}
+	}
+}
+
+func TestDiagramVerticalLarge(t *testing.T) {
+	// The layout of each node is computed once, so large combinators take
+	// about as long as with Diagram
+	for _, term := range []Term{GCD, IS_PRIME} {
+		start := time.Now()
+		v := DiagramVertical(term)
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("DiagramVertical(%s) took %v", term, elapsed)
+		}
+		if v == "" {
+			t.Errorf("DiagramVertical(%s) is empty", term)
+		}
+	}
+}
+
+func TestDiagramASCII(t *testing.T) {
+	if got, expect := DiagramASCII(I), "+\n|"; got != expect {
+		t.Errorf("DiagramASCII(I):\ngot:\n%s\nexpect:\n%s", got, expect)
+	}
+	if got, expect := DiagramASCII(F), "-\n+\n|"; got != expect {
+		t.Errorf("DiagramASCII(F):\ngot:\n%s\nexpect:\n%s", got, expect)
+	}
+
+	for _, term := range []Term{I, K, S, U, OMEGA, Y, ChurchNumeral(3), FACTORIAL} {
+		ascii := DiagramASCII(term)
+		for i := 0; i < len(ascii); i++ {
+			if ascii[i] >= utf8.RuneSelf {
+				t.Fatalf("DiagramASCII(%s) contains non-ASCII byte %#x", term, ascii[i])
+			}
+		}
+
+		// Same layout as the Unicode diagram, blank cell for blank cell
+		unicode := strings.Split(Diagram(term), "\n")
+		lines := strings.Split(ascii, "\n")
+		if len(lines) != len(unicode) {
+			t.Fatalf("DiagramASCII(%s) has %d lines, Diagram %d", term, len(lines), len(unicode))
+		}
+		for r, line := range lines {
+			box := []rune(unicode[r])
+			if len(line) != len(box) {
+				t.Fatalf("DiagramASCII(%s) line %d is %d wide, want %d", term, r, len(line), len(box))
+			}
+			for c := range box {
+				if (box[c] == ' ') != (line[c] == ' ') {
+					t.Fatalf("DiagramASCII(%s) differs from Diagram at %d,%d", term, r, c)
+				}
+			}
+		}
+	}
+}
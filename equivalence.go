@@ -0,0 +1,173 @@
+package lambda
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// AlphaEquivalent reports whether two terms are equal up to renaming of
+// bound variables. Free variables must match by name. LazyScript values are
+// compared through their parsed terms, and the compact Numeral and
+// NumeralApply forms through their Church expansions. LazyScripts with the
+// same text are equivalent without being parsed.
+func AlphaEquivalent(a, b Term) bool {
+	return alphaEquivalent(a, b, nil, nil)
+}
+
+func alphaEquivalent(a, b Term, envA, envB []string) bool {
+	// The same text in the same scope denotes the same term
+	if la, ok := a.(*LazyScript); ok {
+		if lb, ok := b.(*LazyScript); ok && la.script == lb.script && sameEnv(envA, envB) {
+			return true
+		}
+	}
+	a, b = unwrap(a), unwrap(b)
+
+	if na, ok := a.(Numeral); ok {
+		if nb, ok := b.(Numeral); ok {
+			return na == nb
+		}
+		a = na.Expand()
+	}
+	if nb, ok := b.(Numeral); ok {
+		b = nb.Expand()
+	}
+	if na, ok := a.(NumeralApply); ok {
+		a = na.Expand()
+	}
+	if nb, ok := b.(NumeralApply); ok {
+		b = nb.Expand()
+	}
+
+	switch x := a.(type) {
+	case Var:
+		y, ok := b.(Var)
+		if !ok {
+			return false
+		}
+		i, j := binderIndex(envA, x.Name), binderIndex(envB, y.Name)
+		if i != j {
+			return false
+		}
+		// Both free: names must agree
+		return i >= 0 || x.Name == y.Name
+	case Abstraction:
+		y, ok := b.(Abstraction)
+		if !ok {
+			return false
+		}
+		return alphaEquivalent(x.Body, y.Body, append(envA, x.Param), append(envB, y.Param))
+	case Application:
+		y, ok := b.(Application)
+		if !ok {
+			return false
+		}
+		return alphaEquivalent(x.Func, y.Func, envA, envB) && alphaEquivalent(x.Arg, y.Arg, envA, envB)
+	}
+	return false
+}
+
+// Equal reports whether a and b are the same term, bound variable names
+// included. LazyScripts are compared through their parsed terms, but two
+// LazyScripts with the same text, such as two references to a shared
+// constant, are equal at once without being parsed. Compact Numeral and NumeralApply forms
+// are only equal to the same compact forms; use AlphaEquivalent to compare
+// up to renaming and representation.
+func Equal(a, b Term) bool {
+	// Fast path for shared scripts and scripts with the same text
+	if la, ok := a.(*LazyScript); ok {
+		if lb, ok := b.(*LazyScript); ok && (la == lb || la.script == lb.script) {
+			return true
+		}
+	}
+	a, b = unwrap(a), unwrap(b)
+
+	switch x := a.(type) {
+	case Var:
+		y, ok := b.(Var)
+		return ok && x.Name == y.Name
+	case Abstraction:
+		y, ok := b.(Abstraction)
+		return ok && x.Param == y.Param && Equal(x.Body, y.Body)
+	case Application:
+		y, ok := b.(Application)
+		return ok && Equal(x.Func, y.Func) && Equal(x.Arg, y.Arg)
+	case Numeral:
+		y, ok := b.(Numeral)
+		return ok && x == y
+	case NumeralApply:
+		y, ok := b.(NumeralApply)
+		return ok && x.N == y.N && x.Param == y.Param && Equal(x.F, y.F)
+	}
+	return false
+}
+
+// sameEnv reports whether two binder environments bind the same names in
+// the same order.
+func sameEnv(envA, envB []string) bool {
+	if len(envA) != len(envB) {
+		return false
+	}
+	for i := range envA {
+		if envA[i] != envB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// binderIndex returns the de Bruijn index of name in env (innermost binder
+// last), or -1 if the name is not bound.
+func binderIndex(env []string, name string) int {
+	for i := len(env) - 1; i >= 0; i-- {
+		if env[i] == name {
+			return len(env) - 1 - i
+		}
+	}
+	return -1
+}
+
+// Fingerprint returns a canonical string for t such that two terms have
+// the same fingerprint exactly when they are AlphaEquivalent. Bound
+// variables are written as de Bruijn indices (#0 for the innermost binder)
+// and free variables by name, so the fingerprint is suitable as a map key.
+func Fingerprint(t Term) string {
+	var sb strings.Builder
+	fingerprint(&sb, t, nil)
+	return sb.String()
+}
+
+func fingerprint(sb *strings.Builder, t Term, env []string) {
+	switch t := unwrap(t).(type) {
+	case Var:
+		if i := binderIndex(env, t.Name); i >= 0 {
+			fmt.Fprintf(sb, "#%d", i)
+		} else {
+			sb.WriteString(t.Name)
+		}
+	case Abstraction:
+		sb.WriteString("λ")
+		fingerprint(sb, t.Body, append(env, t.Param))
+	case Application:
+		sb.WriteByte('(')
+		fingerprint(sb, t.Func, env)
+		sb.WriteByte(' ')
+		fingerprint(sb, t.Arg, env)
+		sb.WriteByte(')')
+	case Numeral:
+		fingerprint(sb, t.Expand(), env)
+	case NumeralApply:
+		fingerprint(sb, t.Expand(), env)
+	}
+}
+
+// Hash returns a hash of t that is invariant under renaming of bound
+// variables: AlphaEquivalent terms have the same hash. It is computed from
+// the Fingerprint of t, so different terms rarely collide, but callers
+// using it as a map key must still confirm matches with AlphaEquivalent.
+func Hash(t Term) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(Fingerprint(t)))
+	return h.Sum64()
+}
@@ -0,0 +1,192 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestAlphaEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"λx.x", "λy.y", true},
+		{"λx.λy.x", "λa.λb.a", true},
+		{"λx.λy.x", "λa.λb.b", false},
+		{"λx.y", "λz.y", true},
+		{"λx.y", "λy.y", false},
+		{"x", "x", true},
+		{"x", "y", false},
+		{"λx.λx.x", "λa.λb.b", true},
+		{"λx.λx.x", "λa.λb.a", false},
+		{"f x", "f x", true},
+		{"f x", "x f", false},
+		{"λx.x", "x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.a, err)
+			}
+			b, err := Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.b, err)
+			}
+			if got := AlphaEquivalent(a, b); got != tt.want {
+				t.Errorf("AlphaEquivalent(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlphaEquivalentCompactForms(t *testing.T) {
+	if !AlphaEquivalent(Numeral(3), ChurchNumeral(3)) {
+		t.Error("Numeral(3) should be alpha-equivalent to ChurchNumeral(3)")
+	}
+	if AlphaEquivalent(Numeral(3), Numeral(4)) {
+		t.Error("Numeral(3) should not be alpha-equivalent to Numeral(4)")
+	}
+	if !AlphaEquivalent(TRUE, K) {
+		t.Error("TRUE should be alpha-equivalent to K")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"λx.x", "λy.y", true},
+		{"λx.λy.x", "λa.λb.a", true},
+		{"λx.λy.x", "λx.λy.y", false},
+		{"λx.y", "λx.z", false},
+		{"λx.x y", "λz.z y", true},
+		{"_2", "λf.λx.f (f x)", true},
+		{"x y z", "x (y z)", false},
+	}
+
+	for _, tt := range tests {
+		a, err := Parse(tt.a)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.a, err)
+		}
+		b, err := Parse(tt.b)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.b, err)
+		}
+		fa, fb := Fingerprint(a), Fingerprint(b)
+		if (fa == fb) != tt.same {
+			t.Errorf("Fingerprint(%s) = %q, Fingerprint(%s) = %q, same = %v, want %v", tt.a, fa, tt.b, fb, fa == fb, tt.same)
+		}
+		if (fa == fb) != AlphaEquivalent(a, b) {
+			t.Errorf("Fingerprint disagrees with AlphaEquivalent on %s and %s", tt.a, tt.b)
+		}
+	}
+
+	if got := Fingerprint(K); got != "λλ#1" {
+		t.Errorf("Fingerprint(K) = %q, want λλ#1", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	id1, _ := Parse("λx.x")
+	id2, _ := Parse("λy.y")
+	if Hash(id1) != Hash(id2) {
+		t.Errorf("Hash(λx.x) = %x, Hash(λy.y) = %x, want equal", Hash(id1), Hash(id2))
+	}
+	if Hash(Numeral(2)) != Hash(ChurchNumeral(2)) {
+		t.Error("Hash differs between Numeral(2) and its Church form")
+	}
+
+	// Different terms get different hashes
+	seen := make(map[uint64]string)
+	for _, input := range []string{"λx.x", "λx.λy.x", "λx.λy.y", "x", "y", "x y", "λx.x x", "_2", "_3", "_PLUS", "_MULT"} {
+		term, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", input, err)
+		}
+		h := Hash(term)
+		if other, ok := seen[h]; ok {
+			t.Errorf("Hash(%s) collides with Hash(%s)", input, other)
+		}
+		seen[h] = input
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b Term
+		want bool
+	}{
+		{FACTORIAL, FACTORIAL, true},
+		{FACTORIAL, Clone(FACTORIAL), true},
+		{I, Abstraction{Param: "x", Body: Var{Name: "x"}}, true},
+		{I, Abstraction{Param: "y", Body: Var{Name: "y"}}, false}, // α-equivalent only
+		{ChurchNumeral(2), ChurchNumeral(2), true},
+		{ChurchNumeral(2), Numeral(2), false},
+		{Numeral(2), Numeral(2), true},
+		{NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}}, NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}}, true},
+		{NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}}, NumeralApply{N: 3, Param: "x", F: Var{Name: "f"}}, false},
+		{Application{Func: Var{Name: "f"}, Arg: Var{Name: "x"}}, Application{Func: Var{Name: "f"}, Arg: Var{Name: "y"}}, false},
+		{K, S, false},
+	}
+
+	for _, tt := range tests {
+		if got := Equal(tt.a, tt.b); got != tt.want {
+			t.Errorf("Equal(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := Equal(tt.b, tt.a); got != tt.want {
+			t.Errorf("Equal(%s, %s) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestEqualScriptsUnparsed(t *testing.T) {
+	// Text not used by any constant, so that nothing else parses it
+	a := MakeLazyScript(`λunparsed.λy.unparsed y y`)
+	b := MakeLazyScript(`λunparsed.λy.unparsed y y`)
+	if !Equal(a, b) {
+		t.Errorf("Equal = false for scripts with the same text")
+	}
+	if !AlphaEquivalent(a, b) {
+		t.Errorf("AlphaEquivalent = false for scripts with the same text")
+	}
+	// Inside binders, as long as they bind the same names
+	if !AlphaEquivalent(Abstraction{Param: "z", Body: a}, Abstraction{Param: "z", Body: b}) {
+		t.Errorf("AlphaEquivalent = false for scripts under the same binder")
+	}
+	if a.parsed != nil || b.parsed != nil {
+		t.Errorf("comparing scripts with the same text parsed them")
+	}
+
+	// A free variable of the script is bound differently
+	x, y := MakeLazyScript(`x`), MakeLazyScript(`x`)
+	if AlphaEquivalent(Abstraction{Param: "x", Body: x}, Abstraction{Param: "y", Body: y}) {
+		t.Errorf("λx.x and λy.x are not α-equivalent")
+	}
+
+	if Equal(a, MakeLazyScript(`λz.λy.z y y`)) {
+		t.Errorf("Equal = true for scripts with different bound names")
+	}
+	if !AlphaEquivalent(a, MakeLazyScript(`λz.λy.z y y`)) {
+		t.Errorf("AlphaEquivalent = false for α-equivalent scripts")
+	}
+}
+
+// Comparing a shared constant with itself takes the pointer fast path;
+// comparing two separate copies of its parsed term walks both terms, down
+// to the constants they refer to.
+func BenchmarkEqualInterned(b *testing.B) {
+	a, c := FACTORIAL, FACTORIAL
+	for i := 0; i < b.N; i++ {
+		Equal(a, c)
+	}
+}
+
+func BenchmarkEqualFresh(b *testing.B) {
+	a, c := Clone(unwrap(FACTORIAL)), Clone(unwrap(FACTORIAL))
+	for i := 0; i < b.N; i++ {
+		Equal(a, c)
+	}
+}
@@ -0,0 +1,215 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Kinds of results reported by Classify, Describe and Eval.
+const (
+	KindInt    = "int"
+	KindBool   = "bool"
+	KindLambda = "lambda"
+)
+
+// Errors reported by EvalTerm when the result is not of the requested kind.
+var (
+	ErrNotNumeral = errors.New("result is not a valid Church numeral")
+	ErrNotBoolean = errors.New("result is not a valid Church boolean")
+)
+
+// EvalResult is the outcome of EvalTerm.
+type EvalResult struct {
+	Kind      string // KindInt, KindBool or KindLambda
+	IntValue  int    // Value of the result if Kind is KindInt
+	BoolValue bool   // Value of the result if Kind is KindBool
+	Term      Term   // The reduced term
+	Steps     int    // Number of reduction steps performed
+	Complete  bool   // True if the term reached normal form
+	Err       error  // Set if the result is not of the requested kind
+}
+
+// Classify reports what a reduced term looks like: KindInt with its value
+// for a Church numeral, otherwise KindBool with its value for a Church
+// boolean, and KindLambda for any other term. Numerals are tried first, so
+// 0, which is the same term as FALSE, is classified as an int. The term is
+// not reduced; use AsInt and AsBool to test for one kind only.
+func Classify(t Term) (kind string, intVal int, boolVal bool) {
+	if n, ok := AsInt(t); ok {
+		return KindInt, n, false
+	}
+	if b, ok := AsBool(t); ok {
+		return KindBool, 0, b
+	}
+	return KindLambda, 0, false
+}
+
+// LooksLikeNumeral reports whether t has the shape of a Church numeral:
+// two abstractions λf.λx around either x or an application headed by f.
+// Only the head of the body is inspected, so the check is cheap but may
+// accept terms such as λf.λx.f f that AsInt rejects; it is meant to pick
+// what to try first.
+func LooksLikeNumeral(t Term) bool {
+	t = unwrap(t)
+	if _, ok := t.(Numeral); ok {
+		return true
+	}
+	outer, ok := t.(Abstraction)
+	if !ok {
+		return false
+	}
+	switch inner := unwrap(outer.Body).(type) {
+	case Abstraction:
+		switch body := unwrap(inner.Body).(type) {
+		case Var:
+			return body.Name == inner.Param
+		case Application:
+			head, ok := unwrap(applicationHead(body)).(Var)
+			return ok && head.Name == outer.Param && outer.Param != inner.Param
+		}
+	case NumeralApply:
+		if inner.N == 0 {
+			return true
+		}
+		head, ok := unwrap(applicationHead(inner.F)).(Var)
+		return ok && head.Name == outer.Param
+	}
+	return false
+}
+
+// LooksLikeBool reports whether t has the shape of a Church boolean: two
+// abstractions around a variable. Like LooksLikeNumeral it is a quick
+// structural check; AsBool decodes the value.
+func LooksLikeBool(t Term) bool {
+	t = unwrap(t)
+	if n, ok := t.(Numeral); ok {
+		return n == 0
+	}
+	outer, ok := t.(Abstraction)
+	if !ok {
+		return false
+	}
+	switch inner := unwrap(outer.Body).(type) {
+	case Abstraction:
+		_, ok := unwrap(inner.Body).(Var)
+		return ok
+	case NumeralApply:
+		return inner.N == 0
+	}
+	return false
+}
+
+// LooksLikeList reports whether t has the shape of a list: two
+// abstractions λc.λn around either n or a cell c head tail. The elements are
+// not inspected; FromChurchList decodes the list. NIL is the same term as 0
+// and FALSE, so the empty list also looks like a numeral and a boolean.
+func LooksLikeList(t Term) bool {
+	c, n, body, ok := listBinders(t)
+	if !ok {
+		return false
+	}
+	if v, ok := unwrap(body).(Var); ok {
+		return v.Name == n
+	}
+	_, _, ok = matchCons(body, c)
+	return ok
+}
+
+// applicationHead returns the term at the head of a chain of applications.
+func applicationHead(t Term) Term {
+	for {
+		app, ok := unwrap(t).(Application)
+		if !ok {
+			return t
+		}
+		t = app.Func
+	}
+}
+
+// Describe formats a reduced term for display according to Classify: a
+// numeral as its value, a boolean as true or false, and any other term in
+// lambda notation.
+func Describe(t Term) (text string, kind string) {
+	kind, n, b := Classify(t)
+	switch kind {
+	case KindInt:
+		return strconv.Itoa(n), kind
+	case KindBool:
+		return strconv.FormatBool(b), kind
+	}
+	return t.String(), kind
+}
+
+// EvalTerm reduces t for at most maxSteps steps and interprets the result
+// as the given kind. An empty kind classifies the result as Classify does.
+// KindInt and KindBool require a numeral or a boolean, setting Err to
+// ErrNotNumeral or ErrNotBoolean otherwise, and KindLambda leaves the term
+// uninterpreted. On error Kind is KindLambda.
+//
+// Running out of steps is not an error: Complete is false and the partially
+// reduced term is interpreted.
+// If maxSteps is 0 or negative, DefaultStepLimit is used.
+func EvalTerm(t Term, kind string, maxSteps int) EvalResult {
+	return EvalTermWithStrategy(t, kind, maxSteps, NormalOrder)
+}
+
+// EvalTermWithStrategy evaluates t like EvalTerm, reducing it with the
+// given strategy.
+func EvalTermWithStrategy(t Term, kind string, maxSteps int, strategy Strategy) EvalResult {
+	if maxSteps <= 0 {
+		maxSteps = DefaultStepLimit
+	}
+	reduced, steps := ReduceWithStrategy(t, maxSteps, strategy)
+	res := EvalResult{Kind: KindLambda, Term: reduced, Steps: steps, Complete: true}
+	if steps == maxSteps {
+		if _, didReduce := reduced.BetaReduce(); didReduce {
+			res.Complete = false
+		}
+	}
+
+	switch kind {
+	case "":
+		res.Kind, res.IntValue, res.BoolValue = Classify(reduced)
+	case KindInt:
+		if n, ok := AsInt(reduced); ok {
+			res.Kind, res.IntValue = KindInt, n
+		} else {
+			res.Err = ErrNotNumeral
+		}
+	case KindBool:
+		if b, ok := AsBool(reduced); ok {
+			res.Kind, res.BoolValue = KindBool, b
+		} else {
+			res.Err = ErrNotBoolean
+		}
+	case KindLambda:
+	default:
+		res.Err = fmt.Errorf("invalid kind %q", kind)
+	}
+	return res
+}
+
+// Eval parses expr, reduces it for at most maxSteps steps and describes
+// the result as Describe does. It deals only in strings, for callers such
+// as a syscall/js wrapper that cannot easily handle Term values.
+//
+// A parse error is returned as is. If the term is not in normal form after
+// maxSteps steps, the partially reduced term is described and
+// ErrNotNormalized is returned with it.
+// If maxSteps is 0 or negative, DefaultStepLimit is used.
+func Eval(expr string, maxSteps int) (result string, kind string, steps int, err error) {
+	if maxSteps <= 0 {
+		maxSteps = DefaultStepLimit
+	}
+	term, err := Parse(expr)
+	if err != nil {
+		return "", "", 0, err
+	}
+	res := EvalTerm(term, "", maxSteps)
+	if !res.Complete {
+		err = ErrNotNormalized
+	}
+	result, kind = Describe(res.Term)
+	return result, kind, res.Steps, err
+}
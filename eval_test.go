@@ -0,0 +1,163 @@
+package lambda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		term    Term
+		kind    string
+		intVal  int
+		boolVal bool
+	}{
+		{ChurchNumeral(3), KindInt, 3, false},
+		{Numeral(9), KindInt, 9, false},
+		{ChurchNumeral(0), KindInt, 0, false}, // also FALSE
+		{TRUE, KindBool, 0, true},
+		{Abstraction{Param: "x", Body: Var{Name: "x"}}, KindLambda, 0, false},
+		{Application{Func: SUCC, Arg: ChurchNumeral(1)}, KindLambda, 0, false}, // not reduced
+	}
+
+	for _, tt := range tests {
+		kind, n, b := Classify(tt.term)
+		if kind != tt.kind || n != tt.intVal || b != tt.boolVal {
+			t.Errorf("Classify(%s) = %s, %d, %v, want %s, %d, %v", tt.term, kind, n, b, tt.kind, tt.intVal, tt.boolVal)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr   string
+		result string
+		kind   string
+	}{
+		{"_PLUS _2 _3", "5", KindInt},
+		{"_AND _TRUE _FALSE", "0", KindInt}, // FALSE is also 0
+		{"_TRUE", "true", KindBool},
+		{"λx.x", "λx.x", KindLambda},
+		{"(λx.x) y", "y", KindLambda},
+	}
+
+	for _, tt := range tests {
+		result, kind, steps, err := Eval(tt.expr, 1000)
+		if err != nil {
+			t.Errorf("Eval(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if result != tt.result || kind != tt.kind {
+			t.Errorf("Eval(%q) = %q (%s) in %d steps, want %q (%s)", tt.expr, result, kind, steps, tt.result, tt.kind)
+		}
+	}
+
+	if _, _, _, err := Eval("(λx.x", 1000); err == nil {
+		t.Error("Eval of a malformed expression should fail")
+	}
+
+	result, kind, steps, err := Eval("(λx.x x) (λx.x x)", 10)
+	if !errors.Is(err, ErrNotNormalized) || steps != 10 || kind != KindLambda {
+		t.Errorf("Eval(Ω) = %q (%s) in %d steps, %v, want ErrNotNormalized after 10 steps", result, kind, steps, err)
+	}
+
+	// Reaching the limit exactly on a normal form is not an error
+	if _, _, steps, err := Eval("(λx.x) y", 1); err != nil || steps != 1 {
+		t.Errorf("Eval((λx.x) y, 1) took %d steps, %v", steps, err)
+	}
+}
+
+func TestEvalTerm(t *testing.T) {
+	tests := []struct {
+		expr    string
+		kind    string
+		want    string
+		intVal  int
+		boolVal bool
+		err     error
+	}{
+		{"_PLUS _2 _3", "", KindInt, 5, false, nil},
+		{"_NOT _FALSE", "", KindBool, 0, true, nil},
+		{"_AND _TRUE _FALSE", KindBool, KindBool, 0, false, nil},
+		{"_AND _TRUE _FALSE", "", KindInt, 0, false, nil},
+		{"λx.x", KindInt, KindLambda, 0, false, ErrNotNumeral},
+		{"_2", KindBool, KindLambda, 0, false, ErrNotBoolean},
+		{"_2", KindLambda, KindLambda, 0, false, nil},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		res := EvalTerm(term, tt.kind, 1000)
+		if res.Kind != tt.want || res.IntValue != tt.intVal || res.BoolValue != tt.boolVal || !errors.Is(res.Err, tt.err) {
+			t.Errorf("EvalTerm(%s, %q) = %s, %d, %v, %v, want %s, %d, %v, %v", tt.expr, tt.kind,
+				res.Kind, res.IntValue, res.BoolValue, res.Err, tt.want, tt.intVal, tt.boolVal, tt.err)
+		}
+		if !res.Complete {
+			t.Errorf("EvalTerm(%s, %q) did not complete", tt.expr, tt.kind)
+		}
+	}
+
+	res := EvalTerm(OMEGA, "", 10)
+	if res.Complete || res.Steps != 10 || res.Err != nil {
+		t.Errorf("EvalTerm(Ω) = complete %v after %d steps, %v", res.Complete, res.Steps, res.Err)
+	}
+	if res := EvalTerm(I, "float", 10); res.Err == nil {
+		t.Errorf("EvalTerm with an invalid kind has no error")
+	}
+}
+
+func TestLooksLike(t *testing.T) {
+	tests := []struct {
+		expr                   string
+		numeral, boolean, list bool
+	}{
+		{`_3`, true, false, false},
+		{`λf.λx.f (f x)`, true, false, false},
+		{`_0`, true, true, true},
+		{`_TRUE`, false, true, false},
+		{`λa.λb.c`, false, true, false},
+		{`_NIL`, true, true, true},         // the same term as 0
+		{`_PAIR a b`, false, false, false}, // not reduced
+		{`λc.λn.c a n`, true, false, true},
+		{`λx.x`, false, false, false},
+		{`f x`, false, false, false},
+		{`λf.λx.g x`, false, false, false},
+		{`λf.λx.f f`, true, false, false}, // only the head is checked
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := LooksLikeNumeral(term); got != tt.numeral {
+			t.Errorf("LooksLikeNumeral(%s) = %v, want %v", tt.expr, got, tt.numeral)
+		}
+		if got := LooksLikeBool(term); got != tt.boolean {
+			t.Errorf("LooksLikeBool(%s) = %v, want %v", tt.expr, got, tt.boolean)
+		}
+		if got := LooksLikeList(term); got != tt.list {
+			t.Errorf("LooksLikeList(%s) = %v, want %v", tt.expr, got, tt.list)
+		}
+	}
+
+	// Compact numerals, reduction results and arbitrary terms never panic
+	terms := []Term{
+		nil, Numeral(0), Numeral(5),
+		NumeralApply{N: 0, Param: "x", F: Var{Name: "f"}},
+		Abstraction{Param: "f", Body: NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}}},
+		Abstraction{Param: "f", Body: NumeralApply{N: 0, Param: "x", F: Var{Name: "f"}}},
+		Y, FACTORIAL, OMEGA, ChurchList(Var{Name: "a"}),
+	}
+	for _, term := range terms {
+		LooksLikeNumeral(term)
+		LooksLikeBool(term)
+		LooksLikeList(term)
+	}
+	if !LooksLikeNumeral(terms[4]) || !LooksLikeBool(terms[5]) || !LooksLikeList(terms[9]) {
+		t.Errorf("compact forms not recognized")
+	}
+}
@@ -0,0 +1,55 @@
+package lambda
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// Gob encoding
+//
+// The concrete term types are registered with encoding/gob, so a Term, or
+// any struct holding one, can be encoded directly. A LazyScript is encoded
+// through its parsed form and decodes as an already parsed LazyScript.
+
+func init() {
+	gob.Register(Var{})
+	gob.Register(Abstraction{})
+	gob.Register(Application{})
+	gob.Register(Numeral(0))
+	gob.Register(NumeralApply{})
+	gob.Register(&LazyScript{})
+}
+
+// gobTerm wraps a Term so it is encoded as an interface value, with its
+// concrete type.
+type gobTerm struct {
+	T Term
+}
+
+// GobEncode encodes the parsed term of l. It fails if the script does not
+// parse.
+func (l *LazyScript) GobEncode() ([]byte, error) {
+	t, err := l.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobTerm{T: t}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode sets l to an already parsed LazyScript holding the decoded
+// term. l must not have been used before.
+func (l *LazyScript) GobDecode(data []byte) error {
+	var g gobTerm
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	l.script = g.T.String()
+	l.once.Do(func() {
+		l.parsed = g.T
+	})
+	return nil
+}
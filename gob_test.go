@@ -0,0 +1,65 @@
+package lambda
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+func gobRoundTrip(t *testing.T, term Term) Term {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&term); err != nil {
+		t.Fatalf("encoding %s: %v", term, err)
+	}
+	var decoded Term
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("decoding %s: %v", term, err)
+	}
+	return decoded
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	terms := []Term{
+		Var{Name: "x"},
+		Abstraction{Param: "x", Body: Application{Func: Var{Name: "x"}, Arg: Var{Name: "y"}}},
+		ChurchNumeral(3),
+		Numeral(42),
+		NumeralApply{N: 3, Param: "x", F: Var{Name: "f"}},
+	}
+
+	for _, term := range terms {
+		decoded := gobRoundTrip(t, term)
+		if !reflect.DeepEqual(decoded, term) {
+			t.Errorf("round trip of %s = %#v, want %#v", term, decoded, term)
+		}
+	}
+}
+
+func TestGobLazyScript(t *testing.T) {
+	term := Application{Func: FACTORIAL, Arg: ChurchNumeral(2)}
+	decoded := gobRoundTrip(t, term)
+
+	app, ok := decoded.(Application)
+	if !ok {
+		t.Fatalf("decoded %T, want Application", decoded)
+	}
+	ls, ok := app.Func.(*LazyScript)
+	if !ok {
+		t.Fatalf("decoded function %T, want *LazyScript", app.Func)
+	}
+	if ls.String() != FACTORIAL.String() {
+		t.Errorf("decoded script = %s, want %s", ls, FACTORIAL)
+	}
+	if result, _ := Reduce(decoded, 2000); ToInt(result) != 2 {
+		t.Errorf("decoded FACTORIAL 2 reduced to %s, want 2", result)
+	}
+
+	// A malformed script cannot be encoded
+	var buf bytes.Buffer
+	var bad Term = MakeLazyScript("λx.(x")
+	if err := gob.NewEncoder(&buf).Encode(&bad); err == nil {
+		t.Error("encoding a malformed LazyScript should fail")
+	}
+}
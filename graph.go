@@ -0,0 +1,104 @@
+package lambda
+
+import (
+	"errors"
+)
+
+// ErrGraphTooLarge is returned by ReductionGraph when the graph has more
+// nodes than allowed.
+var ErrGraphTooLarge = errors.New("reduction graph exceeds node limit")
+
+// ReductionNode is a node of a reduction graph: a term together with
+// edges to each of its one-step reducts.
+type ReductionNode struct {
+	Term Term
+	Next []*ReductionNode
+}
+
+// ReductionGraph builds the graph of all reduction paths from obj, taking
+// every redex rather than only the normal-order one. Terms that are
+// α-equivalent share a single node, so converging paths meet and cycles
+// (such as Ω → Ω) point back to an existing node.
+//
+// If the graph grows beyond maxNodes nodes, the partial graph built so far
+// is returned along with ErrGraphTooLarge. If maxNodes is 0 or negative, a
+// default limit of 1000 is used.
+func ReductionGraph(obj Term, maxNodes int) (*ReductionNode, error) {
+	if maxNodes <= 0 {
+		maxNodes = 1000
+	}
+
+	root := &ReductionNode{Term: obj}
+	nodes := []*ReductionNode{root}
+	find := func(t Term) *ReductionNode {
+		for _, n := range nodes {
+			if AlphaEquivalent(n.Term, t) {
+				return n
+			}
+		}
+		return nil
+	}
+
+	for queue := []*ReductionNode{root}; len(queue) > 0; queue = queue[1:] {
+		node := queue[0]
+		for _, r := range reducts(node.Term) {
+			next := find(r)
+			if next == nil {
+				if len(nodes) >= maxNodes {
+					return root, ErrGraphTooLarge
+				}
+				next = &ReductionNode{Term: r}
+				nodes = append(nodes, next)
+				queue = append(queue, next)
+			}
+			if !containsNode(node.Next, next) {
+				node.Next = append(node.Next, next)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// reducts returns every term reachable from t in one reduction step,
+// in normal order (leftmost-outermost redex first).
+func reducts(t Term) []Term {
+	switch t := t.(type) {
+	case *LazyScript:
+		return reducts(t.parse())
+	case Abstraction:
+		var res []Term
+		for _, b := range reducts(t.Body) {
+			res = append(res, Abstraction{Param: t.Param, Body: b})
+		}
+		return res
+	case Application:
+		var res []Term
+		if r, ok := t.contract(); ok {
+			res = append(res, r)
+		}
+		for _, f := range reducts(t.Func) {
+			res = append(res, Application{Func: f, Arg: t.Arg})
+		}
+		for _, a := range reducts(t.Arg) {
+			res = append(res, Application{Func: t.Func, Arg: a})
+		}
+		return res
+	case NumeralApply:
+		var res []Term
+		for _, f := range reducts(t.F) {
+			res = append(res, NumeralApply{N: t.N, Param: t.Param, F: f})
+		}
+		return res
+	}
+	return nil
+}
+
+func containsNode(list []*ReductionNode, n *ReductionNode) bool {
+	for _, m := range list {
+		if m == n {
+			return true
+		}
+	}
+	return false
+}
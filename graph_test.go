@@ -0,0 +1,68 @@
+package lambda
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReductionGraphConfluence(t *testing.T) {
+	// (λx.λy.x) ((λz.z) w) has two redexes; both paths end in λy.w
+	term, err := Parse("(λx.λy.x) ((λz.z) w)")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	root, err := ReductionGraph(term, 100)
+	if err != nil {
+		t.Fatalf("ReductionGraph error: %v", err)
+	}
+	if len(root.Next) != 2 {
+		t.Fatalf("root has %d reducts, want 2", len(root.Next))
+	}
+
+	left, right := root.Next[0], root.Next[1]
+	if left.Term.String() != "λy.(λz.z) w" {
+		t.Errorf("outer reduct = %s, want λy.(λz.z) w", left.Term)
+	}
+	if right.Term.String() != "(λx.λy.x) w" {
+		t.Errorf("inner reduct = %s, want (λx.λy.x) w", right.Term)
+	}
+	if len(left.Next) != 1 || len(right.Next) != 1 {
+		t.Fatalf("each branch should have one reduct, got %d and %d", len(left.Next), len(right.Next))
+	}
+	if left.Next[0] != right.Next[0] {
+		t.Errorf("branches should converge on a shared node, got %s and %s", left.Next[0].Term, right.Next[0].Term)
+	}
+
+	nf := left.Next[0]
+	if nf.Term.String() != "λy.w" || len(nf.Next) != 0 {
+		t.Errorf("final node = %s with %d reducts, want λy.w in normal form", nf.Term, len(nf.Next))
+	}
+}
+
+func TestReductionGraphCycle(t *testing.T) {
+	// Ω → Ω: a single node pointing to itself
+	root, err := ReductionGraph(OMEGA, 10)
+	if err != nil {
+		t.Fatalf("ReductionGraph error: %v", err)
+	}
+	if len(root.Next) != 1 || root.Next[0] != root {
+		t.Errorf("Ω should reduce to itself, got %d reducts", len(root.Next))
+	}
+}
+
+func TestReductionGraphLimit(t *testing.T) {
+	// (λx.x x x) (λx.x x x) grows forever
+	term, err := Parse("(λx.x x x) (λx.x x x)")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	root, err := ReductionGraph(term, 5)
+	if !errors.Is(err, ErrGraphTooLarge) {
+		t.Fatalf("err = %v, want ErrGraphTooLarge", err)
+	}
+	if root == nil || root.Term != term {
+		t.Errorf("partial graph should still be rooted at the term")
+	}
+}
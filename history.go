@@ -0,0 +1,91 @@
+package lambda
+
+// ReductionHistory stores the terms of a reduction, in order, with a cursor
+// to step forward and back through them, for example in a debugger.
+// Reduction is deterministic, so it is recorded once and replayed from
+// memory.
+type ReductionHistory struct {
+	terms     []Term
+	pos       int
+	truncated bool
+}
+
+// RecordReduction reduces obj like Reduce, at most limit steps, and
+// records obj and every intermediate term. The cursor starts on obj.
+// If limit is 0 or negative, DefaultStepLimit is used.
+//
+// Intermediate terms can be large, so maxTerms, if positive, caps the
+// number of terms stored, the initial one included: a reduction reaching
+// the cap stops there, and recording can resume from the last term.
+func RecordReduction(obj Term, limit, maxTerms int) *ReductionHistory {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	steps := limit
+	if maxTerms > 0 && maxTerms-1 < steps {
+		steps = maxTerms - 1
+	}
+
+	h := &ReductionHistory{terms: []Term{obj}}
+	if steps == 0 {
+		_, h.truncated = obj.BetaReduce()
+		return h
+	}
+	last, done := ReduceTrace(obj, steps, func(_ int, _, after Term) {
+		h.terms = append(h.terms, after)
+	})
+	if done == steps && steps < limit {
+		_, h.truncated = last.BetaReduce()
+	}
+	return h
+}
+
+// Len returns the number of terms recorded, one more than the number of
+// steps.
+func (h *ReductionHistory) Len() int {
+	return len(h.terms)
+}
+
+// At returns the term after i steps, the initial term being At(0). It
+// panics if i is out of range.
+func (h *ReductionHistory) At(i int) Term {
+	return h.terms[i]
+}
+
+// Truncated reports whether recording stopped at the maxTerms given to
+// RecordReduction while the reduction could go on.
+func (h *ReductionHistory) Truncated() bool {
+	return h.truncated
+}
+
+// Pos returns the position of the cursor, the index of Current.
+func (h *ReductionHistory) Pos() int {
+	return h.pos
+}
+
+// Current returns the term under the cursor.
+func (h *ReductionHistory) Current() Term {
+	return h.terms[h.pos]
+}
+
+// Forward moves the cursor one step forward and returns the term there. It
+// reports false, leaving the cursor on the last term, if there is no next
+// term.
+func (h *ReductionHistory) Forward() (Term, bool) {
+	if h.pos+1 >= len(h.terms) {
+		return h.terms[h.pos], false
+	}
+	h.pos++
+	return h.terms[h.pos], true
+}
+
+// Back moves the cursor one step back and returns the term there. It
+// reports false, leaving the cursor on the initial term, if it is already
+// there.
+func (h *ReductionHistory) Back() (Term, bool) {
+	if h.pos == 0 {
+		return h.terms[0], false
+	}
+	h.pos--
+	return h.terms[h.pos], true
+}
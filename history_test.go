@@ -0,0 +1,52 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestReductionHistory(t *testing.T) {
+	term, err := Parse(`(λx.x) ((λy.y) z)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := RecordReduction(term, 0, 0)
+	if h.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", h.Len())
+	}
+	if h.At(0) != term || h.At(2).String() != "z" || h.Truncated() {
+		t.Errorf("history = %s, %s, %s, truncated %v", h.At(0), h.At(1), h.At(2), h.Truncated())
+	}
+
+	if _, ok := h.Back(); ok || h.Pos() != 0 {
+		t.Errorf("Back() at the start moved to %d", h.Pos())
+	}
+	if got, ok := h.Forward(); !ok || got.String() != "(λy.y) z" {
+		t.Errorf("Forward() = %s, %v", got, ok)
+	}
+	h.Forward()
+	if got, ok := h.Forward(); ok || got.String() != "z" || h.Pos() != 2 {
+		t.Errorf("Forward() at the end = %s, %v at %d", got, ok, h.Pos())
+	}
+	if got, ok := h.Back(); !ok || h.Current() != got || h.Pos() != 1 {
+		t.Errorf("Back() = %s, %v at %d", got, ok, h.Pos())
+	}
+}
+
+func TestReductionHistoryCap(t *testing.T) {
+	h := RecordReduction(OMEGA, 100, 5)
+	if h.Len() != 5 || !h.Truncated() {
+		t.Errorf("Len() = %d, Truncated() = %v, want 5, true", h.Len(), h.Truncated())
+	}
+
+	// A reduction ending exactly at the cap is complete
+	h = RecordReduction(Application{Func: I, Arg: Var{Name: "x"}}, 0, 2)
+	if h.Len() != 2 || h.Truncated() {
+		t.Errorf("Len() = %d, Truncated() = %v, want 2, false", h.Len(), h.Truncated())
+	}
+
+	// So is a reduction stopped by its step limit
+	h = RecordReduction(OMEGA, 3, 4)
+	if h.Len() != 4 || h.Truncated() {
+		t.Errorf("Len() = %d, Truncated() = %v, want 4, false", h.Len(), h.Truncated())
+	}
+}
@@ -0,0 +1,104 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HandlerOptions configures the evaluation budget of Handler.
+type HandlerOptions struct {
+	MaxSteps     int           // Maximum reduction steps per request (default: 10000)
+	Timeout      time.Duration // Maximum reduction time per request (default: 5s)
+	MaxBodyBytes int64         // Maximum size of a POSTed expression (default: 64KiB)
+	MaxDepth     int           // Maximum nesting depth of terms (default: 10000)
+}
+
+// EvalResponse is the JSON response of Handler.
+type EvalResponse struct {
+	Result    string `json:"result"`         // The reduced term
+	Steps     int    `json:"steps"`          // Number of reduction steps performed
+	Int       *int   `json:"int,omitempty"`  // Value of the result if Classify finds a numeral
+	Bool      *bool  `json:"bool,omitempty"` // Value of the result if Classify finds a boolean
+	Truncated bool   `json:"truncated"`      // True if the step, time or depth budget ran out
+}
+
+// Handler returns an http.Handler evaluating lambda expressions. The
+// expression is read from the expr query parameter or, for a POST without
+// it, from the request body. It is reduced within the step and time budget
+// of opts and the outcome is returned as a JSON EvalResponse; a malformed
+// or too deeply nested expression gets a 400 response with a JSON
+// {"error": ...} body. Reduction stops before the term gets deeper than
+// MaxDepth, so no expression can overflow the stack of the server.
+// If opts is nil, the defaults are used.
+func Handler(opts *HandlerOptions) http.Handler {
+	o := HandlerOptions{MaxSteps: 10000, Timeout: 5 * time.Second, MaxBodyBytes: 64 << 10, MaxDepth: 10000}
+	if opts != nil {
+		if opts.MaxSteps > 0 {
+			o.MaxSteps = opts.MaxSteps
+		}
+		if opts.Timeout > 0 {
+			o.Timeout = opts.Timeout
+		}
+		if opts.MaxBodyBytes > 0 {
+			o.MaxBodyBytes = opts.MaxBodyBytes
+		}
+		if opts.MaxDepth > 0 {
+			o.MaxDepth = opts.MaxDepth
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expr := r.URL.Query().Get("expr")
+		switch {
+		case expr != "":
+		case r.Method == http.MethodPost:
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, o.MaxBodyBytes))
+			if err != nil {
+				writeJSONError(w, http.StatusRequestEntityTooLarge, err.Error())
+				return
+			}
+			expr = string(body)
+		case r.Method != http.MethodGet:
+			writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		if expr == "" {
+			writeJSONError(w, http.StatusBadRequest, "missing expression")
+			return
+		}
+
+		term, err := (&Parser{MaxDepth: o.MaxDepth}).Parse(expr)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), o.Timeout)
+		defer cancel()
+		result, steps, err := reduceGuarded(ctx, term, o.MaxSteps, o.MaxDepth)
+
+		res := EvalResponse{
+			Result:    result.String(),
+			Steps:     steps,
+			Truncated: err != nil || steps >= o.MaxSteps,
+		}
+		switch kind, n, b := Classify(result); kind {
+		case KindInt:
+			res.Int = &n
+		case KindBool:
+			res.Bool = &b
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
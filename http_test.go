@@ -0,0 +1,78 @@
+package lambda
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func evalRequest(t *testing.T, h http.Handler, req *http.Request) (int, EvalResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var res EvalResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, res
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(nil)
+
+	// Expression in the query
+	code, res := evalRequest(t, h, httptest.NewRequest("GET", "/?expr="+url.QueryEscape("_PLUS _2 _3"), nil))
+	if code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+	if res.Int == nil || *res.Int != 5 || res.Bool != nil || res.Truncated || res.Steps == 0 {
+		t.Errorf("PLUS 2 3 = %+v, want int 5", res)
+	}
+
+	// Expression in the body
+	code, res = evalRequest(t, h, httptest.NewRequest("POST", "/", strings.NewReader("_OR _FALSE _TRUE")))
+	if code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+	if res.Bool == nil || !*res.Bool || res.Int != nil || res.Result != "λx.λy.x" {
+		t.Errorf("OR FALSE TRUE = %+v, want bool true", res)
+	}
+
+	// Malformed expression
+	code, _ = evalRequest(t, h, httptest.NewRequest("POST", "/", strings.NewReader("(λx.x")))
+	if code != http.StatusBadRequest {
+		t.Errorf("status %d for a malformed expression, want 400", code)
+	}
+}
+
+func TestHandlerBudget(t *testing.T) {
+	h := Handler(&HandlerOptions{MaxSteps: 50})
+	code, res := evalRequest(t, h, httptest.NewRequest("GET", "/?expr="+url.QueryEscape("(λx.x x) (λx.x x)"), nil))
+	if code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+	if !res.Truncated || res.Steps != 50 {
+		t.Errorf("Ω = %+v, want truncated after 50 steps", res)
+	}
+}
+
+func TestHandlerMaxDepth(t *testing.T) {
+	h := Handler(&HandlerOptions{MaxDepth: 200})
+
+	// Too deep to parse
+	code, _ := evalRequest(t, h, httptest.NewRequest("POST", "/", strings.NewReader("_999999")))
+	if code != http.StatusBadRequest {
+		t.Errorf("status %d for _999999, want 400", code)
+	}
+
+	// Growing too deep while reducing
+	code, res := evalRequest(t, h, httptest.NewRequest("POST", "/", strings.NewReader("_MULT _20 _20")))
+	if code != http.StatusOK || !res.Truncated {
+		t.Errorf("MULT 20 20 = %d %+v, want a truncated result", code, res)
+	}
+}
@@ -1,13 +1,27 @@
+// Package lambda implements the untyped lambda calculus: parsing, reduction,
+// Church encodings and Tromp diagrams.
+//
+// Every lambda term, whether parsed, built from Var, Abstraction and
+// Application values, or one of the predefined constants, implements the
+// Term interface, and Term is the only term type used in the API.
 package lambda
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 )
 
 // Term is the interface for all lambda calculus terms
+//
+// Terms are immutable: operations never modify a term, they return a new
+// one that may share unchanged subterms with the original, so terms can be
+// freely shared between goroutines and data structures. Use Clone to get a
+// copy sharing nothing.
 type Term interface {
 	String() string
-	// FreeVars returns the set of free variables in the term
+	// FreeVars returns the set of free variables in the term. The map is
+	// newly allocated and owned by the caller, which may modify it.
 	FreeVars() map[string]bool
 	// Substitute replaces a variable with a term
 	Substitute(varName string, replacement Term) Term
@@ -19,28 +33,74 @@ type Term interface {
 	EtaConvert() (Term, bool)
 }
 
+// All term representations implement Term
+var (
+	_ Term = Var{}
+	_ Term = Abstraction{}
+	_ Term = Application{}
+	_ Term = (*LazyScript)(nil)
+	_ Term = Numeral(0)
+	_ Term = NumeralApply{}
+)
+
 // LazyScript holds an unparsed expression that will be parsed on first use.
 // This allows defining constants that use Parse without creating initialization cycles.
+// Parsing is safe for concurrent first use, and scripts with identical text
+// share a single parsed Term through a package-level cache.
 type LazyScript struct {
 	script string
+	once   sync.Once
 	parsed Term
+	err    error
 }
 
+// scriptCache maps script text to its parsed Term
+var scriptCache sync.Map
+
 // MakeLazyScript creates a new LazyScript from a string
 func MakeLazyScript(script string) *LazyScript {
 	return &LazyScript{script: script}
 }
 
+// ClearScriptCache empties the cache of parsed scripts shared between
+// LazyScript instances. Instances that were already parsed keep their Term.
+func ClearScriptCache() {
+	scriptCache.Range(func(key, _ any) bool {
+		scriptCache.Delete(key)
+		return true
+	})
+}
+
+// Resolve parses the expression on first use and returns the parsed Term,
+// or the parse error if the script is malformed. Unlike the Term methods,
+// which panic on a malformed script, Resolve never panics.
+func (l *LazyScript) Resolve() (Term, error) {
+	l.once.Do(func() {
+		l.parsed, l.err = parseScript(l.script)
+	})
+	return l.parsed, l.err
+}
+
 // parse parses and caches the expression on first use
 func (l *LazyScript) parse() Term {
-	if l.parsed == nil {
-		parsed, err := Parse(l.script)
-		if err != nil {
-			panic(fmt.Sprintf("LazyScript parse error: %v\nScript: %s", err, l.script))
-		}
-		l.parsed = parsed
+	t, err := l.Resolve()
+	if err != nil {
+		panic(fmt.Sprintf("LazyScript parse error: %v\nScript: %s", err, l.script))
 	}
-	return l.parsed
+	return t
+}
+
+// parseScript returns the parsed form of script, from the cache if possible
+func parseScript(script string) (Term, error) {
+	if t, ok := scriptCache.Load(script); ok {
+		return t.(Term), nil
+	}
+	parsed, err := Parse(script)
+	if err != nil {
+		return nil, err
+	}
+	t, _ := scriptCache.LoadOrStore(script, parsed)
+	return t.(Term), nil
 }
 
 func (l *LazyScript) String() string {
@@ -126,19 +186,111 @@ func (v Var) FreeVars() map[string]bool {
 }
 
 func (a Abstraction) FreeVars() map[string]bool {
-	fv := a.Body.FreeVars()
-	delete(fv, a.Param)
+	fv := make(map[string]bool)
+	collectFreeVars(a, nil, fv)
 	return fv
 }
 
 func (a Application) FreeVars() map[string]bool {
-	fv := a.Func.FreeVars()
-	for k := range a.Arg.FreeVars() {
-		fv[k] = true
-	}
+	fv := make(map[string]bool)
+	collectFreeVars(a, nil, fv)
 	return fv
 }
 
+// collectFreeVars adds the free variables of t to fv, where bound counts
+// the enclosing binders of each name. The whole term is walked into the
+// single map owned by the caller, so no map returned by a subterm's
+// FreeVars is ever modified or shared.
+func collectFreeVars(t Term, bound map[string]int, fv map[string]bool) {
+	collectFreeVarsRec(t, bound, fv, 0)
+}
+
+// maxRecursion is the nesting depth past which walks over terms switch
+// from recursion to an explicit stack, so that very deep terms such as
+// large numerals cannot overflow the goroutine stack.
+const maxRecursion = 1000
+
+func collectFreeVarsRec(t Term, bound map[string]int, fv map[string]bool, depth int) {
+	if depth > maxRecursion {
+		collectFreeVarsIter(t, bound, fv)
+		return
+	}
+	switch t := unwrap(t).(type) {
+	case Var:
+		if bound[t.Name] == 0 {
+			fv[t.Name] = true
+		}
+	case Abstraction:
+		if bound == nil {
+			bound = make(map[string]int)
+		}
+		bound[t.Param]++
+		collectFreeVarsRec(t.Body, bound, fv, depth+1)
+		bound[t.Param]--
+	case Application:
+		collectFreeVarsRec(t.Func, bound, fv, depth+1)
+		collectFreeVarsRec(t.Arg, bound, fv, depth+1)
+	case NumeralApply:
+		if bound == nil {
+			bound = make(map[string]int)
+		}
+		bound[t.Param]++
+		collectFreeVarsRec(t.F, bound, fv, depth+1)
+		bound[t.Param]--
+	case Numeral:
+	default:
+		for name := range t.FreeVars() {
+			if bound[name] == 0 {
+				fv[name] = true
+			}
+		}
+	}
+}
+
+// collectFreeVarsIter is collectFreeVars with an explicit stack.
+func collectFreeVarsIter(t Term, bound map[string]int, fv map[string]bool) {
+	if bound == nil {
+		bound = make(map[string]int)
+	}
+
+	// A frame without term leaves the scope of the binder unbind
+	type frame struct {
+		term   Term
+		unbind string
+	}
+	stack := []frame{{term: t}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.term == nil {
+			bound[f.unbind]--
+			continue
+		}
+
+		switch t := unwrap(f.term).(type) {
+		case Var:
+			if bound[t.Name] == 0 {
+				fv[t.Name] = true
+			}
+		case Abstraction:
+			bound[t.Param]++
+			stack = append(stack, frame{unbind: t.Param}, frame{term: t.Body})
+		case Application:
+			stack = append(stack, frame{term: t.Arg}, frame{term: t.Func})
+		case NumeralApply:
+			bound[t.Param]++
+			stack = append(stack, frame{unbind: t.Param}, frame{term: t.F})
+		case Numeral:
+		default:
+			for name := range t.FreeVars() {
+				if bound[name] == 0 {
+					fv[name] = true
+				}
+			}
+		}
+	}
+}
+
 // Substitute implementations
 func (v Var) Substitute(varName string, replacement Term) Term {
 	if v.Name == varName {
@@ -156,15 +308,17 @@ func (a Abstraction) Substitute(varName string, replacement Term) Term {
 	// Check for variable capture
 	if replacement.FreeVars()[a.Param] {
 		// Need α-conversion to avoid capture.
-		// The fresh name must avoid both the replacement's free vars
-		// and the body's free vars to prevent accidental capture.
+		// The fresh name must avoid the replacement's free vars and every
+		// name in the body, free or bound: renaming to the name of an inner
+		// binder would let that binder capture the renamed occurrences. It
+		// must also avoid the substituted name itself, or the renamed
+		// occurrences would be replaced too.
 		avoid := make(map[string]bool)
 		for k := range replacement.FreeVars() {
 			avoid[k] = true
 		}
-		for k := range a.Body.FreeVars() {
-			avoid[k] = true
-		}
+		varNames(a.Body, avoid)
+		avoid[varName] = true
 		newParam := freshVar(a.Param, avoid)
 		newBody := a.Body.AlphaConvert(a.Param, newParam)
 		return Abstraction{Param: newParam, Body: newBody.Substitute(varName, replacement)}
@@ -246,12 +400,29 @@ func (a Application) AlphaConvert(oldName, newName string) Term {
 	}
 }
 
+// DefaultStepLimit is the number of steps used by the functions of this
+// package when given a step limit of 0 or less, and by those without a
+// limit parameter, such as ToInt and ToBool. It is a process-wide setting:
+// raise it once at startup for heavier workloads, before reducing terms
+// concurrently. An explicit positive limit, like Reducer.Limit, always
+// takes precedence.
+var DefaultStepLimit = 1000
+
 // Reduce performs multiple β-reductions up to a maximum number of steps.
 // It returns the reduced term and the number of reductions performed.
-// If limit is 0 or negative, a default limit of 1000 is used.
+// If limit is 0 or negative, DefaultStepLimit is used.
 func Reduce(obj Term, limit int) (Term, int) {
+	return ReduceTrace(obj, limit, nil)
+}
+
+// ReduceTrace reduces obj like Reduce, calling onStep after each step with
+// the step number, starting at 1, and the terms before and after it. Terms
+// are immutable, so onStep can keep them or render them but cannot affect
+// the reduction. A nil onStep is allowed.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceTrace(obj Term, limit int, onStep func(step int, before, after Term)) (Term, int) {
 	if limit <= 0 {
-		limit = 1000
+		limit = DefaultStepLimit
 	}
 
 	steps := 0
@@ -260,8 +431,11 @@ func Reduce(obj Term, limit int) (Term, int) {
 		if !didReduce {
 			break
 		}
-		obj = reduced
 		steps++
+		if onStep != nil {
+			onStep(steps, obj, reduced)
+		}
+		obj = reduced
 	}
 
 	return obj, steps
@@ -282,17 +456,38 @@ func (a Abstraction) BetaReduce() (Term, bool) {
 }
 
 func (a Application) BetaReduce() (Term, bool) {
+	// Check if we can do β-reduction at the top level
+	if result, ok := a.contract(); ok {
+		return result, true
+	}
+
+	// Try to reduce the function
+	newFunc, reduced := a.Func.BetaReduce()
+	if reduced {
+		return Application{Func: newFunc, Arg: a.Arg}, true
+	}
+
+	// Try to reduce the argument
+	newArg, reduced := a.Arg.BetaReduce()
+	if reduced {
+		return Application{Func: a.Func, Arg: newArg}, true
+	}
+
+	return a, false
+}
+
+// contract performs a reduction step at the top level of the application,
+// if it is a redex.
+func (a Application) contract() (Term, bool) {
 	// Unwrap LazyScript if present
 	funcTerm := a.Func
 	if ls, ok := funcTerm.(*LazyScript); ok {
 		funcTerm = ls.parse()
 	}
 
-	// Check if we can do β-reduction at the top level
+	// (λx.t) s → t[x := s]
 	if abs, ok := funcTerm.(Abstraction); ok {
-		// (λx.t) s → t[x := s]
-		result := abs.Body.Substitute(abs.Param, a.Arg)
-		return result, true
+		return abs.Body.Substitute(abs.Param, a.Arg), true
 	}
 
 	// Numeral(n) applied to arg → NumeralApply{n, arg} (partial application)
@@ -306,19 +501,7 @@ func (a Application) BetaReduce() (Term, bool) {
 		return na.expand(a.Arg), true
 	}
 
-	// Try to reduce the function
-	newFunc, reduced := a.Func.BetaReduce()
-	if reduced {
-		return Application{Func: newFunc, Arg: a.Arg}, true
-	}
-
-	// Try to reduce the argument
-	newArg, reduced := a.Arg.BetaReduce()
-	if reduced {
-		return Application{Func: a.Func, Arg: newArg}, true
-	}
-
-	return a, false
+	return nil, false
 }
 
 // EtaConvert implementations
@@ -362,6 +545,15 @@ func (a Application) EtaConvert() (Term, bool) {
 	return a, false
 }
 
+// EtaExpand wraps t into the abstraction λx.t x, the inverse of an
+// η-reduction at the top level. The parameter is named freshName, or a
+// numbered variant of it if that name is free in t, so that t is not
+// captured. EtaConvert of the result gives back t.
+func EtaExpand(t Term, freshName string) Term {
+	x := freshVar(freshName, t.FreeVars())
+	return Abstraction{Param: x, Body: Application{Func: t, Arg: Var{Name: x}}}
+}
+
 // Helper function to generate fresh variable names
 func freshVar(base string, avoid map[string]bool) string {
 	if !avoid[base] {
@@ -377,6 +569,23 @@ func freshVar(base string, avoid map[string]bool) string {
 	}
 }
 
+// varNames adds every variable name occurring in term, bound or free, to names.
+func varNames(term Term, names map[string]bool) {
+	switch t := unwrap(term).(type) {
+	case Var:
+		names[t.Name] = true
+	case Abstraction:
+		names[t.Param] = true
+		varNames(t.Body, names)
+	case Application:
+		varNames(t.Func, names)
+		varNames(t.Arg, names)
+	case NumeralApply:
+		names[t.Param] = true
+		varNames(t.F, names)
+	}
+}
+
 // ChurchNumeral creates a Church numeral for the given natural number
 // n := λf.λx.f^n x
 // 0 := λf.λx.x
@@ -403,8 +612,30 @@ func ChurchNumeral(n int) Term {
 	}
 }
 
+// SubConst returns a function subtracting the constant k from a Church
+// numeral, as a fixed chain of k PREDs:
+// SubConst(k) := λn.PRED (PRED (... (PRED n)))
+// This avoids SUB iterating over a numeral argument when k is known.
+func SubConst(k int) Term {
+	if k < 0 {
+		panic("SubConst is only defined for non-negative constants")
+	}
+
+	var body Term = Var{Name: "n"}
+	for i := 0; i < k; i++ {
+		body = Application{Func: PRED, Arg: body}
+	}
+	return Abstraction{Param: "n", Body: body}
+}
+
 // ToInt converts a Church numeral to a Go integer by applying it to increment and 0
 // Church numeral n = λf.λx.f^n x, so we apply it to a marker function and count applications
+// The term is reduced for at most DefaultStepLimit steps.
+// As it reduces the term, it accepts terms not yet in normal form, but it
+// can be fooled by a term mentioning the marker names SUCC_MARKER and
+// ZERO_MARKER, and it converts anything else to some number. For a term
+// already in normal form, AsInt is faster and exact; ToIntPure reduces
+// the term first and is exact too.
 func ToInt(term Term) int {
 	// Shortcut for compact Numeral type
 	if n, ok := term.(Numeral); ok {
@@ -413,50 +644,228 @@ func ToInt(term Term) int {
 	// Apply the Church numeral to an increment function and 0
 	// Church numeral n applied to f and 0 will call f n times
 	// We'll use a marker to count
-	result := Term(Application{
-		Func: Application{
-			Func: term,
-			Arg:  Var{Name: "SUCC_MARKER"},
-		},
-		Arg: Var{Name: "ZERO_MARKER"},
-	})
+	result := App(term, V("SUCC_MARKER"), V("ZERO_MARKER"))
 
 	// Reduce completely (with a limit to avoid infinite loops)
-	result, _ = Reduce(result, 1000)
+	result, _ = Reduce(result, DefaultStepLimit)
 
 	// Count nested applications of SUCC_MARKER
 	return countApplications(result, "SUCC_MARKER")
 }
 
+// ToIntEta converts a Church numeral to a Go integer up to η-conversion,
+// reporting whether term is one. The term is βη-normalized (with at most
+// DefaultStepLimit β-steps), so η-expanded forms such as λf.λx.(λy.f y) x decode like
+// λf.λx.f x, and the η-reduced λf.f, which POW n 0 reduces to, decodes as 1.
+func ToIntEta(term Term) (int, bool) {
+	nf, ok := Normalize(term, DefaultStepLimit)
+	if !ok {
+		return 0, false
+	}
+	// λf.f is the η-normal form of 1
+	if abs, ok := asAbstraction(nf); ok {
+		if v, ok := unwrap(abs.Body).(Var); ok && v.Name == abs.Param {
+			return 1, true
+		}
+	}
+	return matchNumeral(nf)
+}
+
+// ToIntPure converts a Church numeral to a Go integer without marker
+// variables: the term is reduced to normal form (with at most limit steps,
+// or DefaultStepLimit if limit is 0 or negative) and the numeral is counted
+// structurally, using its own binder names as AsInt does. Free
+// variables of the term, whatever their names, cannot be mistaken for the
+// numeral's f and x. It returns false if no normal form was reached or it
+// is not a numeral.
+func ToIntPure(term Term, limit int) (int, bool) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	nf, ok := normalForm(term, limit)
+	if !ok {
+		return 0, false
+	}
+	return matchNumeral(nf)
+}
+
+// ErrNotNormalized is returned when a term does not reach β-normal form
+// within the allowed number of reduction steps.
+var ErrNotNormalized = errors.New("not normalized within limit")
+
 // ToBool converts a Church boolean to a Go bool
 // TRUE returns true, FALSE returns false
 // Church boolean: TRUE = λx.λy.x, FALSE = λx.λy.y
+// Terms that are not Church booleans convert to false; use ToBoolChecked
+// to tell them apart from FALSE.
 func ToBool(term Term) bool {
-	// Apply the boolean to two distinct markers
-	// TRUE will return the first argument, FALSE will return the second
-	result := Term(Application{
-		Func: Application{
-			Func: term,
-			Arg:  Var{Name: "TRUE_MARKER"},
-		},
-		Arg: Var{Name: "FALSE_MARKER"},
-	})
+	b, _ := ToBoolChecked(term)
+	return b
+}
 
-	// Reduce completely (with a limit to avoid infinite loops)
-	result, _ = Reduce(result, 1000)
+// ToBoolChecked converts a Church boolean to a Go bool, reporting an error
+// when the term is not one. The term is reduced to normal form (with at
+// most DefaultStepLimit steps) and the result must be exactly λx.λy.x or λx.λy.y.
+func ToBoolChecked(term Term) (bool, error) {
+	return toBoolChecked(term, DefaultStepLimit)
+}
+
+// ExplainBool converts a Church boolean like ToBoolChecked, reducing for
+// at most limit steps, and explains the outcome in words: "ok",
+// "not normalized within limit" or "result is not a Church boolean: <form>".
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ExplainBool(term Term, limit int) (bool, string) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	b, err := toBoolChecked(term, limit)
+	if err != nil {
+		return false, err.Error()
+	}
+	return b, "ok"
+}
 
-	// Check which marker we got
-	if v, ok := result.(Var); ok {
-		if v.Name == "TRUE_MARKER" {
-			return true
+func toBoolChecked(term Term, limit int) (bool, error) {
+	nf, ok := normalForm(term, limit)
+	if !ok {
+		return false, ErrNotNormalized
+	}
+	if b, ok := matchBool(nf); ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("result is not a Church boolean: %s", nf)
+}
+
+// normalForm β-reduces term for at most limit steps and reports whether
+// the returned term is in normal form.
+func normalForm(term Term, limit int) (Term, bool) {
+	for i := 0; i < limit; i++ {
+		reduced, didReduce := term.BetaReduce()
+		if !didReduce {
+			return unwrap(term), true
 		}
-		if v.Name == "FALSE_MARKER" {
-			return false
+		term = reduced
+	}
+	_, didReduce := term.BetaReduce()
+	return term, !didReduce
+}
+
+// AsInt reports whether term is already a Church numeral λf.λx.f^n x, or
+// a compact Numeral, and returns n. Unlike ToInt it neither reduces the
+// term nor applies it to marker variables, so it is suitable for inspecting
+// a result after reduction and no free variable of the term can be mistaken
+// for a marker. Use ToInt or ToIntEta for terms that still need reducing.
+func AsInt(term Term) (int, bool) {
+	return matchNumeral(term)
+}
+
+// AsBool reports whether term is already a Church boolean, λx.λy.x or
+// λx.λy.y, and returns its value. Unlike ToBool it does not reduce the term.
+// Note that FALSE and the numeral 0 are the same term.
+func AsBool(term Term) (bool, bool) {
+	return matchBool(term)
+}
+
+// matchBool recognizes the normal forms λx.λy.x (true) and λx.λy.y (false).
+func matchBool(term Term) (bool, bool) {
+	outer, ok := asAbstraction(term)
+	if !ok {
+		return false, false
+	}
+	inner, ok := asAbstraction(outer.Body)
+	if !ok {
+		return false, false
+	}
+	v, ok := unwrap(inner.Body).(Var)
+	if !ok {
+		return false, false
+	}
+	// Check the inner binder first: it shadows the outer one
+	if v.Name == inner.Param {
+		return false, true
+	}
+	if v.Name == outer.Param {
+		return true, true
+	}
+	return false, false
+}
+
+// matchNumeral recognizes the normal form λf.λx.f^n x of a Church numeral
+// and returns n.
+func matchNumeral(term Term) (int, bool) {
+	if n, ok := unwrap(term).(Numeral); ok {
+		return int(n), true
+	}
+	outer, ok := asAbstraction(term)
+	if !ok {
+		return 0, false
+	}
+	inner, ok := asAbstraction(outer.Body)
+	if !ok {
+		return 0, false
+	}
+	count := 0
+	current := unwrap(inner.Body)
+	for {
+		if v, ok := current.(Var); ok && v.Name == inner.Param {
+			return count, true
+		}
+		app, ok := current.(Application)
+		if !ok || outer.Param == inner.Param {
+			return 0, false
+		}
+		if v, ok := unwrap(app.Func).(Var); !ok || v.Name != outer.Param {
+			return 0, false
 		}
+		count++
+		current = unwrap(app.Arg)
+	}
+}
+
+// matchPair recognizes the normal form λp.p a b of a Church pair and
+// returns its components.
+func matchPair(term Term) (Term, Term, bool) {
+	abs, ok := asAbstraction(term)
+	if !ok {
+		return nil, nil, false
+	}
+	outer, ok := unwrap(abs.Body).(Application)
+	if !ok {
+		return nil, nil, false
+	}
+	inner, ok := unwrap(outer.Func).(Application)
+	if !ok {
+		return nil, nil, false
 	}
+	if v, ok := unwrap(inner.Func).(Var); !ok || v.Name != abs.Param {
+		return nil, nil, false
+	}
+	if inner.Arg.FreeVars()[abs.Param] || outer.Arg.FreeVars()[abs.Param] {
+		return nil, nil, false
+	}
+	return inner.Arg, outer.Arg, true
+}
+
+// unwrap returns the parsed term behind a LazyScript, or term itself.
+func unwrap(term Term) Term {
+	if ls, ok := term.(*LazyScript); ok {
+		return ls.parse()
+	}
+	return term
+}
 
-	// Default to false if we can't determine
-	return false
+// asAbstraction views term as an Abstraction, expanding the compact
+// Numeral and NumeralApply forms as needed.
+func asAbstraction(term Term) (Abstraction, bool) {
+	switch t := unwrap(term).(type) {
+	case Abstraction:
+		return t, true
+	case Numeral:
+		return t.Expand().(Abstraction), true
+	case NumeralApply:
+		return t.Expand(), true
+	}
+	return Abstraction{}, false
 }
 
 // Helper function to count nested applications of a specific function
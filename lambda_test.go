@@ -1,6 +1,8 @@
 package lambda
 
 import (
+	"runtime/debug"
+	"sync"
 	"testing"
 )
 
@@ -87,6 +89,30 @@ func TestFreeVars(t *testing.T) {
 	}
 }
 
+func TestFreeVarsIndependent(t *testing.T) {
+	terms := []Term{
+		Var{Name: "x"},
+		Abstraction{Param: "x", Body: Var{Name: "y"}},
+		Application{Func: Var{Name: "x"}, Arg: Var{Name: "y"}},
+		NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}},
+		Numeral(3),
+		MakeLazyScript("λx.x y"),
+	}
+
+	for _, term := range terms {
+		first := term.FreeVars()
+		want := len(first)
+		second := term.FreeVars()
+		first["mutated"] = true
+		if second["mutated"] || len(second) != want {
+			t.Errorf("%s: FreeVars results share a map", term)
+		}
+		if third := term.FreeVars(); third["mutated"] || len(third) != want {
+			t.Errorf("%s: mutating a FreeVars result changed the term", term)
+		}
+	}
+}
+
 func TestSubstitute(t *testing.T) {
 	// x[x := y] = y
 	v := Var{Name: "x"}
@@ -136,6 +162,35 @@ func TestSubstituteCaptureAvoidance(t *testing.T) {
 	}
 }
 
+func TestSubstituteFreshAvoidsVarName(t *testing.T) {
+	// (λf.f x)[f0 := f] must not rename f to f0, which would then be
+	// substituted as well
+	abs := Abstraction{Param: "f", Body: Application{Func: Var{Name: "f"}, Arg: Var{Name: "x"}}}
+	result := abs.Substitute("f0", Var{Name: "f"})
+
+	if !AlphaEquivalent(result, abs) {
+		t.Errorf("(λf.f x)[f0 := f] = %s, want %s", result, abs)
+	}
+}
+
+func TestSubstituteFreshAvoidsInnerBinder(t *testing.T) {
+	// (λy.λy0.y y0 x)[x := y]: renaming y to y0 would let the inner λy0
+	// capture it
+	abs := Abstraction{Param: "y", Body: Abstraction{Param: "y0", Body: Application{
+		Func: Application{Func: Var{Name: "y"}, Arg: Var{Name: "y0"}},
+		Arg:  Var{Name: "x"},
+	}}}
+	result := abs.Substitute("x", Var{Name: "y"})
+
+	want := Abstraction{Param: "z", Body: Abstraction{Param: "w", Body: Application{
+		Func: Application{Func: Var{Name: "z"}, Arg: Var{Name: "w"}},
+		Arg:  Var{Name: "y"},
+	}}}
+	if !AlphaEquivalent(result, want) {
+		t.Errorf("(λy.λy0.y y0 x)[x := y] = %s, want %s", result, want)
+	}
+}
+
 func TestAlphaConvert(t *testing.T) {
 	// λx.x renamed to λy.y
 	abs := Abstraction{Param: "x", Body: Var{Name: "x"}}
@@ -425,6 +480,32 @@ func TestPow(t *testing.T) {
 	}
 }
 
+func TestSubConst(t *testing.T) {
+	tests := []struct {
+		k, n, want int
+	}{
+		{0, 4, 4},
+		{2, 5, 3},
+		{3, 3, 0},
+		{3, 1, 0},
+	}
+
+	for _, tt := range tests {
+		result, _ := Reduce(Application{Func: SubConst(tt.k), Arg: ChurchNumeral(tt.n)}, 1000)
+		if got := ToInt(result); got != tt.want {
+			t.Errorf("SubConst(%d) %d = %d, want %d", tt.k, tt.n, got, tt.want)
+		}
+	}
+
+	// A fixed PRED chain is cheaper than SUB iterating over the numeral
+	_, constSteps := Reduce(Application{Func: SubConst(2), Arg: ChurchNumeral(5)}, 1000)
+	_, subSteps := Reduce(Application{Func: Application{Func: SUB, Arg: ChurchNumeral(5)}, Arg: ChurchNumeral(2)}, 1000)
+	t.Logf("SubConst(2) 5: %d steps, SUB 5 2: %d steps", constSteps, subSteps)
+	if constSteps >= subSteps {
+		t.Errorf("SubConst(2) 5 took %d steps, SUB 5 2 took %d", constSteps, subSteps)
+	}
+}
+
 func TestFactorial(t *testing.T) {
 	// Test factorial(3) = 6
 	three := ChurchNumeral(3)
@@ -596,4 +677,289 @@ func TestNOT(t *testing.T) {
 	if !ToBool(result) {
 		t.Error("Expected NOT FALSE to be true")
 	}
-}
\ No newline at end of file
+}
+
+func TestToBoolChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		term    Term
+		want    bool
+		wantErr bool
+	}{
+		{"TRUE", TRUE, true, false},
+		{"FALSE", FALSE, false, false},
+		{"NOT TRUE", Application{Func: NOT, Arg: TRUE}, false, false},
+		{"shadowed", Abstraction{Param: "x", Body: Abstraction{Param: "x", Body: Var{Name: "x"}}}, false, false},
+		{"identity", I, false, true},
+		{"numeral 2", ChurchNumeral(2), false, true},
+		{"free variable", Var{Name: "x"}, false, true},
+		{"omega", OMEGA, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToBoolChecked(tt.term)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToBoolChecked(%s) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToBoolChecked(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ToBoolChecked(OMEGA); err != ErrNotNormalized {
+		t.Errorf("ToBoolChecked(OMEGA) error = %v, want ErrNotNormalized", err)
+	}
+}
+
+func TestLazyScriptCache(t *testing.T) {
+	const script = `λcache.λtest.cache test`
+	ClearScriptCache()
+
+	a := MakeLazyScript(script)
+	if _, ok := scriptCache.Load(script); ok {
+		t.Fatal("script should not be parsed before first use")
+	}
+	if a.String() != "λcache.λtest.cache test" {
+		t.Errorf("a = %s", a)
+	}
+	if _, ok := scriptCache.Load(script); !ok {
+		t.Fatal("parsed script should be cached")
+	}
+
+	// A second instance with the same text is served from the cache
+	b := MakeLazyScript(script)
+	cached, _ := scriptCache.Load(script)
+	if b.parse() != cached.(Term) {
+		t.Errorf("b should reuse the cached term")
+	}
+
+	ClearScriptCache()
+	if _, ok := scriptCache.Load(script); ok {
+		t.Error("ClearScriptCache should empty the cache")
+	}
+	if a.String() != b.String() {
+		t.Errorf("instances should keep their parsed term after clearing")
+	}
+}
+
+func TestLazyScriptConcurrent(t *testing.T) {
+	ls := MakeLazyScript(`λx.λy.λz.x z (y z)`)
+	var wg sync.WaitGroup
+	results := make([]string, 16)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = ls.String()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, r := range results {
+		if r != "λx.λy.λz.x z (y z)" {
+			t.Errorf("goroutine %d got %s", i, r)
+		}
+	}
+}
+
+func TestLazyScriptResolve(t *testing.T) {
+	good := MakeLazyScript(`λx.λy.x`)
+	term, err := good.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() returned error: %v", err)
+	}
+	if term.String() != "λx.λy.x" {
+		t.Errorf("Resolve() = %s, want λx.λy.x", term)
+	}
+
+	bad := MakeLazyScript(`λx.(x`)
+	if _, err := bad.Resolve(); err == nil {
+		t.Error("Resolve() of a malformed script should return an error")
+	}
+	// The error is remembered
+	if _, err := bad.Resolve(); err == nil {
+		t.Error("second Resolve() of a malformed script should return an error")
+	}
+}
+
+func TestExplainBool(t *testing.T) {
+	tests := []struct {
+		name string
+		term Term
+		want bool
+		why  string
+	}{
+		{"true", Application{Func: NOT, Arg: FALSE}, true, "ok"},
+		{"false", FALSE, false, "ok"},
+		{"diverges", OMEGA, false, "not normalized within limit"},
+		{"numeral", ChurchNumeral(2), false, "result is not a Church boolean: λf.λx.f (f x)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, why := ExplainBool(tt.term, 100)
+			if got != tt.want || why != tt.why {
+				t.Errorf("ExplainBool(%s) = %v, %q; want %v, %q", tt.term, got, why, tt.want, tt.why)
+			}
+		})
+	}
+}
+
+func TestAsIntAsBool(t *testing.T) {
+	if n, ok := AsInt(ChurchNumeral(3)); !ok || n != 3 {
+		t.Errorf("AsInt(3) = %d, %v", n, ok)
+	}
+	if n, ok := AsInt(Numeral(7)); !ok || n != 7 {
+		t.Errorf("AsInt(Numeral(7)) = %d, %v", n, ok)
+	}
+	// No reduction is performed
+	if _, ok := AsInt(Application{Func: SUCC, Arg: ChurchNumeral(2)}); ok {
+		t.Error("AsInt(SUCC 2) should fail on an unreduced term")
+	}
+	if b, ok := AsBool(TRUE); !ok || !b {
+		t.Errorf("AsBool(TRUE) = %v, %v", b, ok)
+	}
+	if b, ok := AsBool(ChurchNumeral(0)); !ok || b {
+		t.Errorf("AsBool(0) = %v, %v, want false", b, ok)
+	}
+	if _, ok := AsBool(ChurchNumeral(2)); ok {
+		t.Error("AsBool(2) should fail")
+	}
+}
+
+func TestAsIntMarkers(t *testing.T) {
+	// ToInt counts the free SUCC_MARKER as an application of f and
+	// returns 1, the structural check is not fooled
+	term, _ := Parse("λf.λx.SUCC_MARKER x")
+	if n, ok := AsInt(term); ok {
+		t.Errorf("AsInt(%s) = %d, should not be a numeral", term, n)
+	}
+	if n, ok := AsInt(ChurchNumeral(4)); !ok || n != 4 {
+		t.Errorf("AsInt(4) = %d, %v", n, ok)
+	}
+}
+
+func TestToIntPure(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+		ok    bool
+	}{
+		// The markers of ToInt are free variables of the term
+		{"_K _2 (SUCC_MARKER ZERO_MARKER)", 2, true},
+		{"(λm.λf.λx.f (f (f x))) (SUCC_MARKER ZERO_MARKER)", 3, true},
+		{"λSUCC_MARKER.λZERO_MARKER.SUCC_MARKER ZERO_MARKER", 1, true},
+		{"_PLUS _2 _3", 5, true},
+		{"λf.λx.SUCC_MARKER x", 0, false},
+		{"λf.λx.f ZERO_MARKER", 0, false},
+		{"_OMEGA", 0, false},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		if got, ok := ToIntPure(term, 0); ok != tt.ok || got != tt.want {
+			t.Errorf("ToIntPure(%s) = %d, %v, want %d, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestToIntEta(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"λf.λx.(λy.f y) x", 1},
+		{"λf.f", 1},
+		{"λf.λx.(λy.f y) ((λy.f y) x)", 2},
+		{"λf.λx.x", 0},
+		{"_POW _2 _0", 1},
+		{"_PLUS _2 _3", 5},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		if got, ok := ToIntEta(term); !ok || got != tt.want {
+			t.Errorf("ToIntEta(%s) = %d, %v, want %d", tt.input, got, ok, tt.want)
+		}
+	}
+
+	for _, input := range []string{"λx.λy.x", "x", "λf.λx.f x x"} {
+		term, _ := Parse(input)
+		if n, ok := ToIntEta(term); ok {
+			t.Errorf("ToIntEta(%s) = %d, want no numeral", input, n)
+		}
+	}
+}
+
+func TestEtaExpand(t *testing.T) {
+	tests := []struct {
+		term Term
+		want string
+	}{
+		{Var{Name: "f"}, "λx.f x"},
+		{Var{Name: "x"}, "λx0.x x0"},
+		{Application{Func: Var{Name: "g"}, Arg: Var{Name: "x"}}, "λx0.g x x0"},
+		{Abstraction{Param: "x", Body: Var{Name: "x"}}, "λx.(λx.x) x"},
+		{K, "λx.(λx.λy.x) x"},
+	}
+
+	for _, tt := range tests {
+		expanded := EtaExpand(tt.term, "x")
+		if expanded.String() != tt.want {
+			t.Errorf("EtaExpand(%s) = %s, want %s", tt.term, expanded, tt.want)
+		}
+		// Expanding then converting is the identity
+		converted, ok := expanded.EtaConvert()
+		if !ok || !AlphaEquivalent(converted, tt.term) {
+			t.Errorf("EtaConvert(%s) = %s, %v, want %s", expanded, converted, ok, tt.term)
+		}
+	}
+}
+
+func TestFreeVarsDeep(t *testing.T) {
+	// With a small stack, a recursive walk of this term overflows it and
+	// crashes the test binary
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+
+	var term Term = Var{Name: "y"}
+	for i := 0; i < 200000; i++ {
+		term = Abstraction{Param: "x", Body: Application{Func: Var{Name: "x"}, Arg: term}}
+	}
+	fv := term.FreeVars()
+	if len(fv) != 1 || !fv["y"] {
+		t.Errorf("FreeVars = %v, want {y}", fv)
+	}
+	if IsClosed(term) {
+		t.Errorf("IsClosed = true for a term with y free")
+	}
+}
+
+func TestDefaultStepLimit(t *testing.T) {
+	defer func(limit int) { DefaultStepLimit = limit }(DefaultStepLimit)
+
+	// FACTORIAL 3 needs 1477 steps
+	expr := Application{Func: FACTORIAL, Arg: ChurchNumeral(3)}
+	if got := ToInt(expr); got == 6 {
+		t.Fatalf("ToInt(FACTORIAL 3) = 6 within the default of %d steps", DefaultStepLimit)
+	}
+
+	DefaultStepLimit = 5000
+	if got := ToInt(expr); got != 6 {
+		t.Errorf("ToInt(FACTORIAL 3) = %d with DefaultStepLimit = 5000, want 6", got)
+	}
+	if _, steps := Reduce(expr, 0); steps != 1477 {
+		t.Errorf("Reduce(FACTORIAL 3, 0) took %d steps, want 1477", steps)
+	}
+	// An explicit limit takes precedence
+	if _, steps := (&Reducer{Limit: 10}).Reduce(expr); steps != 10 {
+		t.Errorf("Reducer{Limit: 10} took %d steps", steps)
+	}
+}
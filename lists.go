@@ -0,0 +1,151 @@
+package lambda
+
+// Lists
+//
+// Lists use the fold encoding: a list is its own right fold, so
+// [x, y, z] is λc.λn.c x (c y (c z n)) and the empty list is
+// NIL := λc.λn.n, the same term as FALSE and 0. The combinators below
+// are folds and need no recursion.
+var (
+	// CONS := λh.λt.λc.λn.c h (t c n) (prepend an element to a list)
+	CONS = MakeLazyScript(`λh.λt.λc.λn.c h (t c n)`)
+
+	// HEAD := λl.l (λh.λt.h) NIL (first element of a non-empty list)
+	HEAD = MakeLazyScript(`λl.l (λh.λt.h) _NIL`)
+
+	// TAIL := λl.FIRST (l (λx.λp.PAIR (SECOND p) (CONS x (SECOND p))) (PAIR NIL NIL))
+	// Rebuilds the list from the end, keeping the previous result one
+	// element behind, the way PRED works on numerals.
+	TAIL = MakeLazyScript(`
+		λl._FIRST
+			(l (λx.λp._PAIR (_SECOND p) (_CONS x (_SECOND p)))
+			   (_PAIR _NIL _NIL))
+	`)
+)
+
+// Higher-order list operations
+var (
+	// MAP := λf.λl.λc.λn.l (λx.c (f x)) n
+	MAP = MakeLazyScript(`λf.λl.λc.λn.l (λx.c (f x)) n`)
+
+	// FOLDR := λf.λz.λl.l f z
+	FOLDR = MakeLazyScript(`λf.λz.λl.l f z`)
+
+	// FOLDL := λf.λz.λl.l (λx.λk.λacc.k (f acc x)) (λacc.acc) z
+	// Folds the list into a function taking the accumulator, so the
+	// elements are combined from the left.
+	FOLDL = MakeLazyScript(`λf.λz.λl.l (λx.λk.λacc.k (f acc x)) (λacc.acc) z`)
+
+	// LENGTH := λl.FOLDR (λx.λn.SUCC n) ZERO l
+	LENGTH = MakeLazyScript(`λl._FOLDR (λx.λn._SUCC n) _ZERO l`)
+
+	// APPEND := λa.λb.λc.λn.a c (b c n)
+	APPEND = MakeLazyScript(`λa.λb.λc.λn.a c (b c n)`)
+
+	// REVERSE := λl.FOLDL (λacc.λx.CONS x acc) NIL l
+	REVERSE = MakeLazyScript(`λl._FOLDL (λacc.λx._CONS x acc) _NIL l`)
+
+	// COUNT := λp.λl.FOLDR (λx.λacc.PLUS (BOOLTONUM (p x)) acc) 0 l
+	// Counts the elements satisfying the predicate p
+	COUNT = MakeLazyScript(`λp.λl._FOLDR (λx.λacc._PLUS (_B2N (p x)) acc) _0 l`)
+)
+
+// Bounded loops
+var (
+	// RANGE := λn.SECOND (n (λp.PAIR (SUCC (FIRST p)) (λt.SECOND p (CONS (FIRST p) t))) (PAIR ZERO I)) NIL
+	// Builds the list [0,1,...,n-1]. The iteration carries the next index
+	// and a difference list, so elements come out in ascending order.
+	RANGE = MakeLazyScript(`
+		λn._SECOND
+			(n (λp._PAIR (_SUCC (_FIRST p)) (λt._SECOND p (_CONS (_FIRST p) t)))
+			   (_PAIR _ZERO (λt.t)))
+			_NIL
+	`)
+
+	// TIMES := λn.λf.λx.n f x (apply f n times to the seed x)
+	TIMES = MakeLazyScript(`λn.λf.λx.n f x`)
+)
+
+// ChurchList builds the list of the given items, in normal form
+// λc.λn.c item1 (c item2 (... n)).
+func ChurchList(items ...Term) Term {
+	avoid := make(map[string]bool)
+	for _, item := range items {
+		for k := range item.FreeVars() {
+			avoid[k] = true
+		}
+	}
+	c := freshVar("c", avoid)
+	avoid[c] = true
+	n := freshVar("n", avoid)
+
+	var list Term = Var{Name: n}
+	for i := len(items) - 1; i >= 0; i-- {
+		list = Application{
+			Func: Application{Func: Var{Name: c}, Arg: items[i]},
+			Arg:  list,
+		}
+	}
+	return Abstraction{Param: c, Body: Abstraction{Param: n, Body: list}}
+}
+
+// FromChurchList converts a list back to its elements. The term is reduced
+// to normal form first (with at most DefaultStepLimit steps), so the
+// elements are returned in normal form. It returns false if the term is not
+// a list. Since NIL is the same term as FALSE and 0, those convert to the
+// empty list.
+func FromChurchList(t Term) ([]Term, bool) {
+	nf, ok := normalForm(t, DefaultStepLimit)
+	if !ok {
+		return nil, false
+	}
+	c, n, body, ok := listBinders(nf)
+	if !ok {
+		return nil, false
+	}
+	var items []Term
+	for {
+		if v, ok := unwrap(body).(Var); ok && v.Name == n {
+			return items, true
+		}
+		head, rest, ok := matchCons(body, c)
+		if !ok {
+			return nil, false
+		}
+		fv := head.FreeVars()
+		if fv[c] || fv[n] {
+			return nil, false
+		}
+		items = append(items, head)
+		body = rest
+	}
+}
+
+// listBinders returns the two binders λc.λn of a list and its body.
+func listBinders(t Term) (c, n string, body Term, ok bool) {
+	outer, ok := asAbstraction(t)
+	if !ok {
+		return "", "", nil, false
+	}
+	inner, ok := asAbstraction(outer.Body)
+	if !ok || outer.Param == inner.Param {
+		return "", "", nil, false
+	}
+	return outer.Param, inner.Param, inner.Body, true
+}
+
+// matchCons recognizes the cell c head rest of a list body.
+func matchCons(body Term, c string) (head, rest Term, ok bool) {
+	outer, ok := unwrap(body).(Application)
+	if !ok {
+		return nil, nil, false
+	}
+	inner, ok := unwrap(outer.Func).(Application)
+	if !ok {
+		return nil, nil, false
+	}
+	if v, ok := unwrap(inner.Func).(Var); !ok || v.Name != c {
+		return nil, nil, false
+	}
+	return inner.Arg, outer.Arg, true
+}
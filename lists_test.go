@@ -0,0 +1,169 @@
+package lambda
+
+import (
+	"testing"
+)
+
+// intList builds a Church list of Church numerals.
+func intList(ns ...int) Term {
+	items := make([]Term, len(ns))
+	for i, n := range ns {
+		items[i] = ChurchNumeral(n)
+	}
+	return ChurchList(items...)
+}
+
+// listInts decodes a Church list of Church numerals.
+func listInts(t *testing.T, term Term) []int {
+	t.Helper()
+	items, ok := FromChurchList(term)
+	if !ok {
+		t.Fatalf("not a Church list: %s", term)
+	}
+	ns := make([]int, len(items))
+	for i, item := range items {
+		ns[i] = ToInt(item)
+	}
+	return ns
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestChurchListRoundTrip(t *testing.T) {
+	for _, want := range [][]int{{}, {0}, {1, 2, 3}} {
+		if got := listInts(t, intList(want...)); !equalInts(got, want) {
+			t.Errorf("FromChurchList(ChurchList(%v)) = %v", want, got)
+		}
+	}
+}
+
+func TestChurchListBinders(t *testing.T) {
+	// Elements named like the binders must not be captured
+	items := []Term{Var{Name: "c"}, Var{Name: "n"}}
+	got, ok := FromChurchList(ChurchList(items...))
+	if !ok || len(got) != 2 || got[0] != items[0] || got[1] != items[1] {
+		t.Errorf("FromChurchList(ChurchList(c, n)) = %v, %v", got, ok)
+	}
+}
+
+func TestFromChurchListInvalid(t *testing.T) {
+	for _, term := range []Term{I, ChurchNumeral(2), Var{Name: "x"}} {
+		if _, ok := FromChurchList(term); ok {
+			t.Errorf("FromChurchList(%s) should fail", term)
+		}
+	}
+}
+
+func TestListCombinators(t *testing.T) {
+	tests := []struct {
+		name string
+		term Term
+		want []int
+	}{
+		{"MAP SUCC [1,2,3]", Application{Func: Application{Func: MAP, Arg: SUCC}, Arg: intList(1, 2, 3)}, []int{2, 3, 4}},
+		{"MAP SUCC []", Application{Func: Application{Func: MAP, Arg: SUCC}, Arg: intList()}, []int{}},
+		{"APPEND [1,2] [3]", Application{Func: Application{Func: APPEND, Arg: intList(1, 2)}, Arg: intList(3)}, []int{1, 2, 3}},
+		{"APPEND [] [4]", Application{Func: Application{Func: APPEND, Arg: intList()}, Arg: intList(4)}, []int{4}},
+		{"REVERSE [1,2,3]", Application{Func: REVERSE, Arg: intList(1, 2, 3)}, []int{3, 2, 1}},
+		{"CONS 0 [1]", Application{Func: Application{Func: CONS, Arg: ChurchNumeral(0)}, Arg: intList(1)}, []int{0, 1}},
+		{"TAIL [1,2]", Application{Func: TAIL, Arg: intList(1, 2)}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reduced, steps := Reduce(tt.term, 5000)
+			got := listInts(t, reduced)
+			t.Logf("%s = %v in %d steps", tt.name, got, steps)
+			if !equalInts(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFolds(t *testing.T) {
+	tests := []struct {
+		name string
+		term Term
+		want int
+	}{
+		{"FOLDR PLUS 0 [1,2,3]", Application{Func: Application{Func: Application{Func: FOLDR, Arg: PLUS}, Arg: ChurchNumeral(0)}, Arg: intList(1, 2, 3)}, 6},
+		{"FOLDL PLUS 0 [1,2,3]", Application{Func: Application{Func: Application{Func: FOLDL, Arg: PLUS}, Arg: ChurchNumeral(0)}, Arg: intList(1, 2, 3)}, 6},
+		{"FOLDR SUB 5 [3]", Application{Func: Application{Func: Application{Func: FOLDR, Arg: SUB}, Arg: ChurchNumeral(5)}, Arg: intList(3)}, 0},
+		{"FOLDL SUB 5 [3]", Application{Func: Application{Func: Application{Func: FOLDL, Arg: SUB}, Arg: ChurchNumeral(5)}, Arg: intList(3)}, 2},
+		{"LENGTH [1,2,3]", Application{Func: LENGTH, Arg: intList(1, 2, 3)}, 3},
+		{"HEAD [7,8]", Application{Func: HEAD, Arg: intList(7, 8)}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AssertEquivalent(t, tt.term, ChurchNumeral(tt.want), 5000)
+		})
+	}
+}
+
+func TestListParsed(t *testing.T) {
+	expr, err := Parse("_LENGTH (_CONS _1 (_CONS _2 _NIL))")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	AssertEquivalent(t, expr, ChurchNumeral(2), 5000)
+}
+
+func TestCOUNT(t *testing.T) {
+	expr, err := Parse("_COUNT _ISEVEN (_CONS _1 (_CONS _2 (_CONS _3 (_CONS _4 _NIL))))")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	// About 200 steps, most of them spent in ISEVEN
+	reduced, steps := Reduce(expr, 5000)
+	t.Logf("COUNT ISEVEN [1,2,3,4] took %d steps", steps)
+	if got := ToInt(reduced); got != 2 {
+		t.Errorf("COUNT ISEVEN [1,2,3,4] = %d, want 2", got)
+	}
+
+	empty := Application{Func: Application{Func: COUNT, Arg: ISEVEN}, Arg: NIL}
+	AssertEquivalent(t, empty, ChurchNumeral(0), 5000)
+}
+
+func TestRANGE(t *testing.T) {
+	for n, want := range [][]int{{}, {0}, {0, 1}, {0, 1, 2}} {
+		reduced, _ := Reduce(Application{Func: RANGE, Arg: ChurchNumeral(n)}, 5000)
+		if got := listInts(t, reduced); !equalInts(got, want) {
+			t.Errorf("RANGE %d = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestRangeLoops(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"_FOLDR _PLUS _0 (_RANGE _4)", 6},
+		{"_FOLDR (λx.λacc._MULT (_SUCC x) acc) _1 (_RANGE _3)", 6},
+		{"_TIMES _3 _SUCC _2", 5},
+		{"_TIMES _0 _SUCC _2", 2},
+		{"_TIMES _3 (_MULT _2) _1", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			AssertEquivalent(t, expr, ChurchNumeral(tt.want), 10000)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package lambda
+
+// Memoized reduction
+//
+// ReduceMemo normalizes terms in normal order like Reduce, but remembers
+// the normal form of every closed application it fully reduces, keyed by
+// Hash. When an α-equivalent subterm turns up again, typically a copy of
+// an argument duplicated by an earlier step, its normal form is reused
+// instead of being computed again.
+//
+// Only closed subterms are cached. The normal form of a closed term does
+// not depend on where it occurs, so reusing it is sound; an open subterm
+// could in principle be cached too, but its normal form is only meaningful
+// under the same free variables, and keeping to closed terms makes the
+// cache safe to share between unrelated reductions. Subterms whose
+// reduction was cut short by the step limit are never cached.
+
+// memoReducer holds the state of one ReduceMemo run.
+type memoReducer struct {
+	steps int
+	limit int
+	cache map[uint64]Term // normal forms
+	heads map[uint64]Term // weak head normal forms
+}
+
+// ReduceMemo reduces obj to normal form, reusing and filling cache with
+// the normal forms of closed subterms. The same cache may be passed to
+// several calls to share work between them; if cache is nil, a cache
+// local to this call is used. It returns the reduced term and the number
+// of steps performed, which does not include steps saved by the cache.
+//
+// Cache entries are keyed by Hash alone, so two different terms with the
+// same 64-bit hash would be confused; with FNV-1a over the Fingerprint
+// this is vanishingly unlikely.
+//
+// If the limit is reached, the partially reduced term is returned. If limit
+// is 0 or negative, DefaultStepLimit is used.
+func ReduceMemo(obj Term, limit int, cache map[uint64]Term) (Term, int) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	if cache == nil {
+		cache = make(map[uint64]Term)
+	}
+
+	r := &memoReducer{limit: limit, cache: cache, heads: make(map[uint64]Term)}
+	result, _ := r.normalize(obj)
+	return result, r.steps
+}
+
+// normalize reduces t to normal form, reporting false if the step limit
+// was reached first.
+func (r *memoReducer) normalize(t Term) (Term, bool) {
+	t = unwrap(t)
+
+	// Only applications can contain work worth caching
+	var key uint64
+	_, cacheable := t.(Application)
+	cacheable = cacheable && IsClosed(t)
+	if cacheable {
+		key = Hash(t)
+		if nf, ok := r.cache[key]; ok {
+			return nf, true
+		}
+	}
+
+	w, done := r.whnf(t)
+	if !done {
+		return w, false
+	}
+
+	var nf Term
+	switch w := w.(type) {
+	case Abstraction:
+		body, ok := r.normalize(w.Body)
+		nf, done = Abstraction{Param: w.Param, Body: body}, ok
+	case Application:
+		// Stuck: the head is a variable
+		fn, ok := r.normalize(w.Func)
+		if !ok {
+			return Application{Func: fn, Arg: w.Arg}, false
+		}
+		arg, ok := r.normalize(w.Arg)
+		nf, done = Application{Func: fn, Arg: arg}, ok
+	case NumeralApply:
+		f, ok := r.normalize(w.F)
+		nf, done = NumeralApply{N: w.N, Param: w.Param, F: f}, ok
+	default:
+		nf = w
+	}
+
+	if cacheable && done {
+		r.cache[key] = nf
+	}
+	return nf, done
+}
+
+// whnf reduces t until it is no longer a redex at the top or along the
+// spine of its head, reporting false if the step limit was reached first.
+// The results for closed applications are cached as well: a copied
+// argument usually reaches head position before it is normalized.
+func (r *memoReducer) whnf(t Term) (Term, bool) {
+	t = unwrap(t)
+	app, ok := t.(Application)
+	if !ok || !IsClosed(app) {
+		return r.headReduce(t)
+	}
+	key := Hash(app)
+	if w, ok := r.heads[key]; ok {
+		return w, true
+	}
+	w, done := r.headReduce(app)
+	if done {
+		r.heads[key] = w
+	}
+	return w, done
+}
+
+// headReduce does the work of whnf.
+func (r *memoReducer) headReduce(t Term) (Term, bool) {
+	for {
+		app, ok := unwrap(t).(Application)
+		if !ok {
+			return unwrap(t), true
+		}
+		fn, done := r.whnf(app.Func)
+		app = Application{Func: fn, Arg: app.Arg}
+		if !done {
+			return app, false
+		}
+		if _, ok := app.contract(); !ok {
+			return app, true
+		}
+		if r.steps >= r.limit {
+			return app, false
+		}
+		r.steps++
+		t, _ = app.contract()
+	}
+}
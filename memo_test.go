@@ -0,0 +1,77 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestReduceMemo(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"_PLUS _2 _3", 5},
+		{"_MULT _3 _4", 12},
+		{"_FACTORIAL _3", 6},
+		{"(λx._PLUS x x) (_FACTORIAL _3)", 12},
+		{"_GCD _6 _4", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			result, steps := ReduceMemo(expr, 100000, nil)
+			_, plain := Reduce(expr, 100000)
+			t.Logf("%s: ReduceMemo %d steps, Reduce %d steps", tt.input, steps, plain)
+			if got := ToInt(result); got != tt.want {
+				t.Errorf("ReduceMemo(%s) = %s, want %d", tt.input, result, tt.want)
+			}
+		})
+	}
+}
+
+func TestReduceMemoSharedCache(t *testing.T) {
+	expr, _ := Parse("_FACTORIAL _3")
+	cache := make(map[uint64]Term)
+	first, steps := ReduceMemo(expr, 100000, cache)
+	if len(cache) == 0 {
+		t.Fatal("cache is empty after a reduction")
+	}
+
+	// The whole term is now cached
+	second, again := ReduceMemo(expr, 100000, cache)
+	if again != 0 || !AlphaEquivalent(first, second) {
+		t.Errorf("second run took %d steps (first %d) and gave %s, want 0 steps and %s", again, steps, second, first)
+	}
+}
+
+func TestReduceMemoLimit(t *testing.T) {
+	cache := make(map[uint64]Term)
+	_, steps := ReduceMemo(OMEGA, 10, cache)
+	if steps != 10 {
+		t.Errorf("steps = %d, want 10", steps)
+	}
+	if len(cache) != 0 {
+		t.Errorf("cache holds %d entries for a reduction that did not finish", len(cache))
+	}
+
+	// Arguments that are discarded are never reduced
+	expr := Application{Func: Application{Func: K, Arg: Var{Name: "y"}}, Arg: OMEGA}
+	if result, _ := ReduceMemo(expr, 100, nil); result.String() != "y" {
+		t.Errorf("ReduceMemo(K y Ω) = %s, want y", result)
+	}
+}
+
+func TestReduceMemoPrimality(t *testing.T) {
+	for n, want := range map[int]bool{2: true, 3: true, 4: false} {
+		expr := Application{Func: IS_PRIME, Arg: ChurchNumeral(n)}
+		result, steps := ReduceMemo(expr, 50000, nil)
+		_, plain := Reduce(expr, 50000)
+		t.Logf("IS_PRIME %d: ReduceMemo %d steps, Reduce %d steps", n, steps, plain)
+		if got := ToBool(result); got != want {
+			t.Errorf("IS_PRIME %d = %v, want %v", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package lambda
+
+// Fast minimum and maximum
+//
+// MIN and MAX compare their operands with LEQ, which goes through SUB and
+// costs thousands of steps for small numbers. MINF and MAXF are the same
+// functions, and Reduce reduces them exactly like MIN and MAX, but a Reducer
+// with NumericFastPath recognizes them applied to two numerals and computes
+// the smaller or larger one in a single step.
+var (
+	// MINF := λa.λb.IF (LEQ a b) a b, computed directly on numerals
+	MINF = MakeLazyScript(`λa.λb._IF (_LEQ a b) a b`)
+
+	// MAXF := λa.λb.IF (LEQ a b) b a, computed directly on numerals
+	MAXF = MakeLazyScript(`λa.λb._IF (_LEQ a b) b a`)
+)
+
+// MinNumerals returns the smaller of the Church numerals m and n, which must
+// be in normal form. It reports false if one of them is not a numeral. Like
+// MIN, it returns m when they are equal.
+func MinNumerals(m, n Term) (Term, bool) {
+	a, ok := matchNumeral(m)
+	if !ok {
+		return nil, false
+	}
+	b, ok := matchNumeral(n)
+	if !ok {
+		return nil, false
+	}
+	if a <= b {
+		return m, true
+	}
+	return n, true
+}
+
+// MaxNumerals returns the larger of the Church numerals m and n, which must
+// be in normal form. It reports false if one of them is not a numeral.
+func MaxNumerals(m, n Term) (Term, bool) {
+	a, ok := matchNumeral(m)
+	if !ok {
+		return nil, false
+	}
+	b, ok := matchNumeral(n)
+	if !ok {
+		return nil, false
+	}
+	if a <= b {
+		return n, true
+	}
+	return m, true
+}
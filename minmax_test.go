@@ -0,0 +1,72 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestMINFMAXF(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"_MAXF _7 _3", 7},
+		{"_MINF _7 _3", 3},
+		{"_MAXF _3 _7", 7},
+		{"_MINF _0 _5", 0},
+		{"_MAXF _4 _4", 4},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		reduced, steps := (&Reducer{Limit: 10, NumericFastPath: true}).Reduce(expr)
+		if got := ToInt(reduced); got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.expr, got, tt.want)
+		}
+		if steps != 1 {
+			t.Errorf("%s took %d steps, want 1", tt.expr, steps)
+		}
+	}
+}
+
+func TestMINFMAXFPure(t *testing.T) {
+	// Without the fast path, MINF reduces step by step exactly like MIN
+	expr, _ := Parse("_MINF _7 _3")
+	want, _ := Parse("_MIN _7 _3")
+	got, steps := Reduce(expr, 10000)
+	wantNF, wantSteps := Reduce(want, 10000)
+	if !AlphaEquivalent(got, wantNF) || steps != wantSteps {
+		t.Errorf("MINF 7 3 = %s in %d steps, MIN 7 3 = %s in %d steps", got, steps, wantNF, wantSteps)
+	}
+}
+
+func TestMINFMAXFNotNumerals(t *testing.T) {
+	// Operands that are not yet numerals are compared like MIN and MAX do
+	expr, _ := Parse("_MAXF (_PLUS _1 _2) _2")
+	reduced, _ := Reduce(expr, 5000)
+	if got := ToInt(reduced); got != 3 {
+		t.Errorf("MAXF (PLUS 1 2) 2 = %d, want 3", got)
+	}
+
+	expr, _ = Parse("λx._MINF x _2")
+	want, _ := Parse("λx._MIN x _2")
+	got, _ := Reduce(expr, 5000)
+	wantNF, _ := Reduce(want, 5000)
+	if !AlphaEquivalent(got, wantNF) {
+		t.Errorf("MINF x 2 = %s, want %s", got, wantNF)
+	}
+}
+
+func TestMinMaxNumerals(t *testing.T) {
+	if got, ok := MinNumerals(ChurchNumeral(2), Numeral(5)); !ok || ToInt(got) != 2 {
+		t.Errorf("MinNumerals(2, 5) = %v, %v", got, ok)
+	}
+	if got, ok := MaxNumerals(ChurchNumeral(2), Numeral(5)); !ok || ToInt(got) != 5 {
+		t.Errorf("MaxNumerals(2, 5) = %v, %v", got, ok)
+	}
+	if _, ok := MaxNumerals(Var{Name: "x"}, ChurchNumeral(1)); ok {
+		t.Errorf("MaxNumerals accepted a variable")
+	}
+}
@@ -65,10 +65,10 @@ func (na NumeralApply) String() string {
 }
 
 func (na NumeralApply) FreeVars() map[string]bool {
-	fv := na.F.FreeVars()
 	// Param is bound — by construction F does not contain Param free,
-	// but delete it defensively.
-	delete(fv, na.Param)
+	// but it is treated as a binder defensively.
+	fv := make(map[string]bool)
+	collectFreeVars(na, nil, fv)
 	return fv
 }
 
@@ -118,6 +118,15 @@ func (na NumeralApply) EtaConvert() (Term, bool) {
 	return na, false
 }
 
+// Expand converts a NumeralApply to the abstraction λparam.F^N(param).
+func (na NumeralApply) Expand() Abstraction {
+	var body Term = Var{Name: na.Param}
+	for i := uint64(0); i < na.N; i++ {
+		body = Application{Func: na.F, Arg: body}
+	}
+	return Abstraction{Param: na.Param, Body: body}
+}
+
 // expand builds the full application chain F^n(x), collapsing nested
 // NumeralApplys first (multiplication optimization).
 func (na NumeralApply) expand(x Term) Term {
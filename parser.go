@@ -1,15 +1,61 @@
 package lambda
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
-// Parser for lambda calculus expressions
+// Parser for lambda calculus expressions.
+// The zero value parses with the default conventions; set the option
+// fields before calling Parse to change them.
 type Parser struct {
+	// RightAssocApp makes application right-associative, so that
+	// f x y parses as f (x y) instead of (f x) y.
+	RightAssocApp bool
+
+	// RequireClosed rejects terms with free variables, returning a
+	// *ClosedError naming them.
+	RequireClosed bool
+
+	// LambdaSymbols lists the characters introducing an abstraction.
+	// If empty, λ and \ are accepted. A non-empty list replaces these
+	// defaults rather than adding to them, so list λ and \ along with, for
+	// instance, Λ or the fullwidth backslash ＼ to keep accepting them.
+	LambdaSymbols []rune
+
+	// MaxDepth, if positive, rejects terms nested deeper than MaxDepth,
+	// as measured by Depth, with an error wrapping ErrTooDeep. Parsing
+	// stops as soon as the nesting or a numeral literal gets too deep, so
+	// untrusted input cannot exhaust the stack or memory.
+	MaxDepth int
+
+	// Infix accepts the arithmetic operators + - * and the comparisons
+	// == < <= > >= between applications, and bare numbers like 42 for the
+	// numerals. Operators are left-associative; * binds tighter than + and
+	// -, which bind tighter than the comparisons, and application binds
+	// tighter than all of them. They stand for PLUS, SUB, MULT, EQ, LT, LEQ,
+	// GT and GEQ, so 2 + 3 * 4 is _PLUS _2 (_MULT _3 _4).
+	Infix bool
+
+	// Keywords accepts true and false for TRUE and FALSE, and
+	// if C then A else B for IF C A B, where the else branch extends as far
+	// right as possible, like the body of an abstraction. The words if,
+	// then, else, true and false are then reserved and cannot name
+	// variables; without Keywords they are ordinary names.
+	Keywords bool
+
+	// surface adds the let and fun forms of Compile, reserving let, rec,
+	// in and fun
+	surface bool
+
+	// The state of one call, set on the copy Parse works on
 	input string
 	pos   int
+	depth int
 }
 
 // Parse parses a lambda expression string and returns the corresponding Term
@@ -18,7 +64,17 @@ type Parser struct {
 //   - Abstraction: λx.body or \x.body
 //   - Application: f x or (f x)
 //   - Parentheses for grouping: (expr)
+//   - Local definitions: expr where { f = ...; g = ... }
+//   - List literals: [a, b, c] for _CONS a (_CONS b (_CONS c _NIL)), [] for _NIL
 func Parse(input string) (Term, error) {
+	return (&Parser{}).Parse(input)
+}
+
+// Parse parses input using the parser's options. See the package-level
+// Parse for the supported syntax. Parse works on a copy of the parser, so
+// one Parser may be used from several goroutines at once as long as its
+// options are not changed meanwhile.
+func (p *Parser) Parse(input string) (Term, error) {
 	input = strings.TrimSpace(input)
 
 	// First, check for balanced parentheses
@@ -26,7 +82,15 @@ func Parse(input string) (Term, error) {
 		return nil, err
 	}
 
-	p := &Parser{input: input, pos: 0}
+	q := *p
+	q.input = input
+	q.pos = 0
+	q.depth = 0
+	return q.parse()
+}
+
+// parse parses the whole input of a parser set up by Parse
+func (p *Parser) parse() (Term, error) {
 	result, err := p.parseExpr()
 	if err != nil {
 		return nil, err
@@ -38,6 +102,14 @@ func Parse(input string) (Term, error) {
 		return nil, fmt.Errorf("unexpected characters after expression at position %d: %q", p.pos, p.input[p.pos:])
 	}
 
+	if p.MaxDepth > 0 && Depth(result) > p.MaxDepth {
+		return nil, fmt.Errorf("%w: depth over %d", ErrTooDeep, p.MaxDepth)
+	}
+
+	if p.RequireClosed && !IsClosed(result) {
+		return nil, &ClosedError{Free: FreeVarNames(result)}
+	}
+
 	return result, nil
 }
 
@@ -67,6 +139,12 @@ func checkBalancedParens(input string) error {
 
 // parseExpr parses a complete expression
 func (p *Parser) parseExpr() (Term, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.MaxDepth > 0 && p.depth > p.MaxDepth {
+		return nil, fmt.Errorf("%w: nesting deeper than %d at position %d", ErrTooDeep, p.MaxDepth, p.pos)
+	}
+
 	p.skipWhitespace()
 
 	if p.pos >= len(p.input) {
@@ -74,32 +152,93 @@ func (p *Parser) parseExpr() (Term, error) {
 	}
 
 	// Check for lambda abstraction
-	if p.peekRune() == 'λ' || p.peek() == '\\' {
+	if p.atLambda() {
 		return p.parseAbstraction()
 	}
 
-	// Parse application (left-associative)
-	return p.parseApplication()
+	var result Term
+	var err error
+	if p.Infix {
+		result, err = p.parseInfix(0)
+	} else {
+		// Parse application (left-associative)
+		result, err = p.parseApplication()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.parseWhere(result)
+}
+
+// infixOperator is a binary operator and the combinator it stands for
+type infixOperator struct {
+	symbol string
+	term   Term
+}
+
+// infixOperators lists the infix operators by precedence level from the
+// loosest, longest symbols first so that <= is not read as <
+var infixOperators = [][]infixOperator{
+	{{"==", EQ}, {"<=", LEQ}, {">=", GEQ}, {"<", LT}, {">", GT}},
+	{{"+", PLUS}, {"-", SUB}},
+	{{"*", MULT}},
+}
+
+// parseInfix parses the left-associative operators of the given precedence
+// level and above, with applications as operands
+func (p *Parser) parseInfix(level int) (Term, error) {
+	if level == len(infixOperators) {
+		return p.parseApplication()
+	}
+	left, err := p.parseInfix(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipWhitespace()
+		op, ok := p.matchOperator(infixOperators[level])
+		if !ok {
+			return left, nil
+		}
+		p.pos += len(op.symbol)
+		right, err := p.parseInfix(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = Application{Func: Application{Func: op.term, Arg: left}, Arg: right}
+	}
+}
+
+// matchOperator returns the operator of ops at the current position
+func (p *Parser) matchOperator(ops []infixOperator) (infixOperator, bool) {
+	for _, op := range ops {
+		if strings.HasPrefix(p.input[p.pos:], op.symbol) {
+			return op, true
+		}
+	}
+	return infixOperator{}, false
 }
 
 // parseAbstraction parses a lambda abstraction: λx.body or \x.body
 func (p *Parser) parseAbstraction() (Term, error) {
 	// Consume lambda symbol
-	if p.peekRune() == 'λ' {
-		p.pos += len("λ") // λ is multi-byte UTF-8
-	} else if p.peek() == '\\' {
-		p.pos++
-	} else {
+	if !p.atLambda() {
 		return nil, fmt.Errorf("expected λ or \\ at position %d", p.pos)
 	}
+	_, size := utf8.DecodeRuneInString(p.input[p.pos:])
+	p.pos += size
 
 	p.skipWhitespace()
 
 	// Parse parameter name
+	start := p.pos
 	param := p.parseIdentifier()
 	if param == "" {
 		return nil, fmt.Errorf("expected parameter name at position %d", p.pos)
 	}
+	if p.isKeyword(param) {
+		return nil, fmt.Errorf("reserved word %q used as parameter at position %d", param, start)
+	}
 
 	p.skipWhitespace()
 
@@ -118,16 +257,18 @@ func (p *Parser) parseAbstraction() (Term, error) {
 	return Abstraction{Param: param, Body: body}, nil
 }
 
-// parseApplication parses function application (left-associative)
+// parseApplication parses function application (left-associative,
+// or right-associative if RightAssocApp is set)
 // Examples: f x, f x y (= (f x) y), (f x) y
 func (p *Parser) parseApplication() (Term, error) {
 	// Parse the first term
-	left, err := p.parseTerm()
+	first, err := p.parseTerm()
 	if err != nil {
 		return nil, err
 	}
+	terms := []Term{first}
 
-	// Keep parsing terms and building left-associative applications
+	// Keep parsing terms
 	for {
 		p.skipWhitespace()
 
@@ -142,17 +283,38 @@ func (p *Parser) parseApplication() (Term, error) {
 		}
 
 		// Try to parse another term
+		start := p.pos
 		right, err := p.parseTerm()
+		if errors.Is(err, ErrTooDeep) {
+			return nil, err
+		}
 		if err != nil {
+			if p.Keywords && p.pos != start {
+				// A malformed if, not the end of the application
+				return nil, err
+			}
 			// Not an error, just no more terms
 			break
 		}
 
-		// Build application (left-associative)
-		left = Application{Func: left, Arg: right}
+		terms = append(terms, right)
+	}
+
+	if p.RightAssocApp {
+		// f x y = f (x y)
+		result := terms[len(terms)-1]
+		for i := len(terms) - 2; i >= 0; i-- {
+			result = Application{Func: terms[i], Arg: result}
+		}
+		return result, nil
 	}
 
-	return left, nil
+	// f x y = (f x) y
+	result := terms[0]
+	for _, t := range terms[1:] {
+		result = Application{Func: result, Arg: t}
+	}
+	return result, nil
 }
 
 // parseTerm parses a single term (variable or parenthesized expression)
@@ -180,17 +342,69 @@ func (p *Parser) parseTerm() (Term, error) {
 		return expr, nil
 	}
 
+	// Check for list literal
+	if p.peek() == '[' {
+		p.pos++
+		return p.parseList()
+	}
+
 	// Check for lambda abstraction
-	if p.peekRune() == 'λ' || p.peek() == '\\' {
+	if p.atLambda() {
 		return p.parseAbstraction()
 	}
 
+	// Bare numbers stand for numerals with infix operators
+	if p.Infix && p.peek() >= '0' && p.peek() <= '9' {
+		start := p.pos
+		for p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		n, err := strconv.Atoi(p.input[start:p.pos])
+		if err != nil {
+			return nil, fmt.Errorf("invalid number at position %d: %w", start, err)
+		}
+		if p.MaxDepth > 0 && n > p.MaxDepth-3 {
+			return nil, fmt.Errorf("%w: numeral %d deeper than %d", ErrTooDeep, n, p.MaxDepth)
+		}
+		return ChurchNumeral(n), nil
+	}
+
 	// Parse variable or constant
+	start := p.pos
 	name := p.parseIdentifier()
 	if name == "" {
 		return nil, fmt.Errorf("expected variable or '(' at position %d", p.pos)
 	}
 
+	// where { ... } ends the expression before it
+	if p.isWhereWord(name, start) {
+		p.pos = start
+		return nil, fmt.Errorf("unexpected where clause at position %d", start)
+	}
+
+	if p.isKeyword(name) {
+		switch name {
+		case "true":
+			return TRUE, nil
+		case "false":
+			return FALSE, nil
+		case "if":
+			return p.parseIf()
+		case "let":
+			return p.parseLet()
+		case "fun":
+			return p.parseFun()
+		}
+		// then, else and in end the application before them
+		p.pos = start
+		return nil, fmt.Errorf("unexpected %q at position %d", name, start)
+	}
+
+	// A numeral _n has depth n+3
+	if n, ok := numeralLiteral(name); ok && p.MaxDepth > 0 && n > p.MaxDepth-3 {
+		return nil, fmt.Errorf("%w: numeral %s deeper than %d", ErrTooDeep, name, p.MaxDepth)
+	}
+
 	// Check if it's a constant (starts with underscore)
 	if len(name) > 0 && name[0] == '_' {
 		if obj, ok := lookupConstant(name); ok {
@@ -201,27 +415,104 @@ func (p *Parser) parseTerm() (Term, error) {
 	return Var{Name: name}, nil
 }
 
+// parseList parses the rest of a list literal [a, b, c], after the '[',
+// into CONS a (CONS b (CONS c NIL)). A trailing comma is allowed.
+func (p *Parser) parseList() (Term, error) {
+	var items []Term
+	for {
+		p.skipWhitespace()
+		if p.peek() == ']' {
+			p.pos++
+			break
+		}
+		item, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipWhitespace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("expected ',' or ']' at position %d", p.pos)
+		}
+	}
+
+	var list Term = NIL
+	for i := len(items) - 1; i >= 0; i-- {
+		list = Application{Func: Application{Func: CONS, Arg: items[i]}, Arg: list}
+	}
+	return list, nil
+}
+
+// parseIf parses the rest of if C then A else B, after the if
+func (p *Parser) parseIf() (Term, error) {
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("then"); err != nil {
+		return nil, err
+	}
+	then, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("else"); err != nil {
+		return nil, err
+	}
+	els, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return Application{Func: Application{Func: Application{Func: IF, Arg: cond}, Arg: then}, Arg: els}, nil
+}
+
+// expectKeyword consumes the keyword word
+func (p *Parser) expectKeyword(word string) error {
+	p.skipWhitespace()
+	start := p.pos
+	if p.parseIdentifier() != word {
+		p.pos = start
+		return fmt.Errorf("expected %q at position %d", word, start)
+	}
+	return nil
+}
+
+// isKeyword reports whether name is reserved by the Keywords option
+func (p *Parser) isKeyword(name string) bool {
+	if !p.Keywords {
+		return false
+	}
+	switch name {
+	case "if", "then", "else", "true", "false":
+		return true
+	case "let", "rec", "in", "fun":
+		return p.surface
+	}
+	return false
+}
+
 // parseIdentifier parses a variable name
 func (p *Parser) parseIdentifier() string {
 	start := p.pos
 
 	// First character must be a letter or underscore
-	if p.pos < len(p.input) {
-		r := rune(p.input[p.pos])
-		if unicode.IsLetter(r) || r == '_' {
-			p.pos++
-		} else {
-			return ""
-		}
-	} else {
+	r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+	if p.pos >= len(p.input) || !(unicode.IsLetter(r) || r == '_') || p.isLambda(r) {
 		return ""
 	}
+	p.pos += size
 
-	// Subsequent characters can be letters, digits, or underscores
+	// Subsequent characters can be letters, digits, underscores, or primes
+	// as in x' and x''
 	for p.pos < len(p.input) {
-		r := rune(p.input[p.pos])
-		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
-			p.pos++
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '\'') && !p.isLambda(r) {
+			p.pos += size
 		} else {
 			break
 		}
@@ -232,8 +523,12 @@ func (p *Parser) parseIdentifier() string {
 
 // skipWhitespace skips whitespace characters
 func (p *Parser) skipWhitespace() {
-	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
-		p.pos++
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		p.pos += size
 	}
 }
 
@@ -250,99 +545,181 @@ func (p *Parser) peekRune() rune {
 	if p.pos >= len(p.input) {
 		return 0
 	}
-	// Check if it's the lambda character (λ = U+03BB)
-	if p.pos+1 < len(p.input) && p.input[p.pos] == 0xCE && p.input[p.pos+1] == 0xBB {
-		return 'λ'
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return r
+}
+
+// atLambda reports whether the current character introduces an abstraction
+func (p *Parser) atLambda() bool {
+	return p.pos < len(p.input) && p.isLambda(p.peekRune())
+}
+
+// isLambda reports whether r is one of the parser's lambda symbols
+func (p *Parser) isLambda(r rune) bool {
+	if len(p.LambdaSymbols) == 0 {
+		return r == 'λ' || r == '\\'
+	}
+	for _, sym := range p.LambdaSymbols {
+		if r == sym {
+			return true
+		}
 	}
-	return rune(p.input[p.pos])
+	return false
+}
+
+// numeralLiteral parses a digit constant _n or _Nn and returns n
+func numeralLiteral(name string) (int, bool) {
+	if len(name) < 2 || name[0] != '_' {
+		return 0, false
+	}
+	digits := name[1:]
+	if len(name) >= 3 && name[1] == 'N' {
+		digits = name[2:]
+	}
+	num := 0
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
+		num = num*10 + int(digits[i]-'0')
+	}
+	return num, true
+}
+
+// constants maps the names of the defined constants, such as _K or
+// _FACTORIAL, to their values
+var constants = map[string]Term{
+	"_I":            I,
+	"_K":            K,
+	"_S":            S,
+	"_B":            B,
+	"_C":            C,
+	"_W":            W,
+	"_U":            U,
+	"_OMEGA":        OMEGA,
+	"_OMEGA_LOWER":  OMEGA_LOWER,
+	"_DELTA":        DELTA,
+	"_TRUE":         TRUE,
+	"_FALSE":        FALSE,
+	"_T":            T,
+	"_F":            F,
+	"_AND":          AND,
+	"_OR":           OR,
+	"_NOT":          NOT,
+	"_IF":           IF,
+	"_IFTHENELSE":   IFTHENELSE,
+	"_ZERO":         ZERO,
+	"_ONE":          ONE,
+	"_TWO":          TWO,
+	"_DEC":          DEC,
+	"_ADD":          ADD,
+	"_SUCC":         SUCC,
+	"_PLUS":         PLUS,
+	"_SUB":          SUB,
+	"_MULT":         MULT,
+	"_POW":          POW,
+	"_MOD":          MOD,
+	"_ISZERO":       ISZERO,
+	"_LEQ":          LEQ,
+	"_LT":           LT,
+	"_EQ":           EQ,
+	"_GT":           GT,
+	"_GEQ":          GEQ,
+	"_CMP":          CMP,
+	"_MAX":          MAX,
+	"_MIN":          MIN,
+	"_MAXF":         MAXF,
+	"_MINF":         MINF,
+	"_GCD":          GCD,
+	"_PAIR":         PAIR,
+	"_FIRST":        FIRST,
+	"_SECOND":       SECOND,
+	"_PHI":          PHI,
+	"_PRED":         PRED,
+	"_STEP2":        STEP2,
+	"_INIT2":        INIT2,
+	"_DIV2":         DIV2,
+	"_ISODD":        ISODD,
+	"_ISEVEN":       ISEVEN,
+	"_B2N":          BOOLTONUM,
+	"_N2B":          NUMTOBOOL,
+	"_DOUBLE":       DOUBLE,
+	"_HALVE":        HALVE,
+	"_POPCOUNT":     POPCOUNT,
+	"_ITERSTATE":    ITERSTATE,
+	"_MUL":          MUL,
+	"_POWMOD":       POWMOD,
+	"_POWMOD_PRIME": POWMOD_PRIME,
+	"_NIL":          NIL,
+	"_NULL":         NULL,
+	"_CONS":         CONS,
+	"_HEAD":         HEAD,
+	"_TAIL":         TAIL,
+	"_MAP":          MAP,
+	"_FOLDR":        FOLDR,
+	"_FOLDL":        FOLDL,
+	"_LENGTH":       LENGTH,
+	"_APPEND":       APPEND,
+	"_REVERSE":      REVERSE,
+	"_COUNT":        COUNT,
+	"_RANGE":        RANGE,
+	"_TIMES":        TIMES,
+	"_Y":            Y,
+	"_FACTORIAL":    FACTORIAL,
+	"_FAC":          FAC,
+	"_FIB":          FIB,
+	"_IS_PRIME":     IS_PRIME,
+	"_TWODEC":       TWODEC,
+	"_DECOMPOSE":    DECOMPOSE,
+	"_LET":          LET,
+	"_IS_LESS2":     IS_LESS2,
+	"_IS_SMALL":     IS_SMALL,
+	"_MR_PASS":      MR_PASS,
+	"_MR_SCAN":      MR_SCAN,
+	"_SINT":         SINT,
+	"_NEG":          NEG,
+	"_SINT_ADD":     SINT_ADD,
+	"_SINT_SUB":     SINT_SUB,
+	"_SINT_NEG":     SINT_NEG,
+	"_EVAL":         SELF_EVAL,
+	"_M":            M,
+	"_MOCKINGBIRD":  MOCKINGBIRD,
+	"_IDIOT":        IDIOT,
+	"_KESTREL":      KESTREL,
+	"_KITE":         KITE,
+	"_STARLING":     STARLING,
+	"_BLUEBIRD":     BLUEBIRD,
+	"_CARDINAL":     CARDINAL,
+	"_WARBLER":      WARBLER,
+	"_VIREO":        VIREO,
+	"_THRUSH":       THRUSH,
+	"_ROBIN":        ROBIN,
+	"_LARK":         LARK,
+}
+
+// Constants returns the defined constants the parser accepts, by name with
+// their leading underscore, such as "_K" or "_FACTORIAL". Numeral literals
+// like _42 are not listed. The map is a copy and can be modified freely.
+func Constants() map[string]Term {
+	c := make(map[string]Term, len(constants))
+	for name, value := range constants {
+		c[name] = value
+	}
+	return c
 }
 
 // lookupConstant looks up a constant by name and returns its value
 // Supports digit constants (_0, _1, _2, ...) and defined constants
 func lookupConstant(name string) (Term, bool) {
-	// Check for digit constants (_0, _1, _2, ...)
-	if len(name) >= 2 && name[0] == '_' {
-		isDigit := true
-		for i := 1; i < len(name); i++ {
-			if name[i] < '0' || name[i] > '9' {
-				isDigit = false
-				break
-			}
-		}
-		if isDigit {
-			// Parse the digit and return Church numeral
-			num := 0
-			for i := 1; i < len(name); i++ {
-				num = num*10 + int(name[i]-'0')
-			}
-			return ChurchNumeral(num), true
-		}
+	// Check for digit constants (_0, _1, _2, ..., or _N0, _N1, _N2, ...)
+	if num, ok := numeralLiteral(name); ok {
+		return ChurchNumeral(num), true
 	}
 
 	// Check for defined constants
-	constants := map[string]Term{
-		"_I":          I,
-		"_K":          K,
-		"_S":          S,
-		"_B":          B,
-		"_C":          C,
-		"_W":          W,
-		"_U":          U,
-		"_OMEGA":      OMEGA,
-		"_OMEGA_LOWER": OMEGA_LOWER,
-		"_DELTA":      DELTA,
-		"_TRUE":       TRUE,
-		"_FALSE":      FALSE,
-		"_T":          T,
-		"_F":          F,
-		"_AND":        AND,
-		"_OR":         OR,
-		"_NOT":        NOT,
-		"_IF":         IF,
-		"_IFTHENELSE": IFTHENELSE,
-		"_ZERO":       ZERO,
-		"_ONE":        ONE,
-		"_TWO":        TWO,
-		"_DEC":        DEC,
-		"_ADD":        ADD,
-		"_SUCC":       SUCC,
-		"_PLUS":       PLUS,
-		"_SUB":        SUB,
-		"_MULT":       MULT,
-		"_POW":        POW,
-		"_MOD":        MOD,
-		"_ISZERO":     ISZERO,
-		"_LEQ":        LEQ,
-		"_LT":         LT,
-		"_EQ":         EQ,
-		"_MAX":        MAX,
-		"_MIN":        MIN,
-		"_GCD":        GCD,
-		"_PAIR":       PAIR,
-		"_FIRST":      FIRST,
-		"_SECOND":     SECOND,
-		"_PHI":        PHI,
-		"_PRED":       PRED,
-		"_STEP2":      STEP2,
-		"_INIT2":      INIT2,
-		"_DIV2":       DIV2,
-		"_ISODD":      ISODD,
-		"_ISEVEN":     ISEVEN,
-		"_MUL":        MUL,
-		"_POWMOD":     POWMOD,
-		"_POWMOD_PRIME": POWMOD_PRIME,
-		"_NIL":        NIL,
-		"_NULL":       NULL,
-		"_Y":          Y,
-		"_FACTORIAL":  FACTORIAL,
-		"_FAC":        FAC,
-		"_FIB":        FIB,
-		"_IS_PRIME":   IS_PRIME,
-	}
-
 	if obj, ok := constants[name]; ok {
 		return obj, true
 	}
 
 	return nil, false
-}
\ No newline at end of file
+}
@@ -1,6 +1,7 @@
 package lambda
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -17,6 +18,9 @@ func TestParseDigitConstants(t *testing.T) {
 		{"_10", 10},
 		{"_42", 42},
 		{"_100", 100},
+		{"_N0", 0},
+		{"_N5", 5},
+		{"_N42", 42},
 		{"_ZERO", 0},
 		{"_ONE", 1},
 	}
@@ -36,6 +40,33 @@ func TestParseDigitConstants(t *testing.T) {
 	}
 }
 
+func TestParseNumeralSynonyms(t *testing.T) {
+	a, err := Parse("_N42")
+	if err != nil {
+		t.Fatalf("Parse(_N42) error: %v", err)
+	}
+	b, _ := Parse("_42")
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Parse(_N42) = %s, want %s", a, b)
+	}
+
+	// Constants starting with _N are not numerals
+	for name, want := range map[string]Term{
+		"_NIL": NIL,
+		"_NOT": NOT,
+		"_N2B": NUMTOBOOL,
+		"_N":   Var{Name: "_N"},
+	} {
+		term, err := Parse(name)
+		if err != nil {
+			t.Fatalf("Parse(%s) error: %v", name, err)
+		}
+		if term != want {
+			t.Errorf("Parse(%s) = %s, want %s", name, term, want)
+		}
+	}
+}
+
 func TestParseNamedConstants(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -68,6 +99,42 @@ func TestParseNamedConstants(t *testing.T) {
 	}
 }
 
+func TestParseLibraryConstants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Term
+	}{
+		{"_EQ", EQ},
+		{"_MAX", MAX},
+		{"_MIN", MIN},
+		{"_GCD", GCD},
+		{"_MOD", MOD},
+		{"_POWMOD", POWMOD},
+		{"_POWMOD_PRIME", POWMOD_PRIME},
+		{"_IS_PRIME", IS_PRIME},
+		{"_TWODEC", TWODEC},
+		{"_DECOMPOSE", DECOMPOSE},
+		{"_LET", LET},
+		{"_IS_LESS2", IS_LESS2},
+		{"_IS_SMALL", IS_SMALL},
+		{"_MR_PASS", MR_PASS},
+		{"_MR_SCAN", MR_SCAN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+
+			if expr.String() != tt.expected.String() {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, expr.String(), tt.expected.String())
+			}
+		})
+	}
+}
+
 func TestParseConstantsInExpressions(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -126,6 +193,10 @@ func TestParseBooleanConstants(t *testing.T) {
 		{"_LEQ _3 _5", true},
 		{"_LEQ _5 _5", true},
 		{"_LEQ _7 _5", false},
+		{"_GT _5 _3", true},
+		{"_GT _5 _5", false},
+		{"_GEQ _5 _5", true},
+		{"_GEQ _3 _5", false},
 	}
 
 	for _, tt := range tests {
@@ -142,4 +213,33 @@ func TestParseBooleanConstants(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestConstants(t *testing.T) {
+	c := Constants()
+	for _, name := range []string{"_I", "_K", "_S", "_Y", "_TRUE", "_FALSE", "_SUCC", "_PLUS", "_PAIR", "_FACTORIAL", "_IS_PRIME"} {
+		if _, ok := c[name]; !ok {
+			t.Errorf("Constants() is missing %s", name)
+		}
+	}
+	if c["_K"] != K {
+		t.Errorf("Constants()[_K] = %s, want K", c["_K"])
+	}
+	if _, ok := c["_42"]; ok {
+		t.Errorf("Constants() lists numeral literals")
+	}
+
+	// Every constant parses back to itself
+	for name, value := range c {
+		got, err := Parse(name)
+		if err != nil || got != value {
+			t.Errorf("Parse(%q) = %v, %v", name, got, err)
+		}
+	}
+
+	// The map is a copy
+	delete(c, "_K")
+	if _, ok := Constants()["_K"]; !ok {
+		t.Errorf("deleting from Constants() affected the parser")
+	}
+}
@@ -1,6 +1,10 @@
 package lambda
 
 import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -347,4 +351,396 @@ func TestParseBackslashNotation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseRightAssocApp(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedStr string
+	}{
+		{"f x y", "f (x y)"},
+		{"f x y z", "f (x (y z))"},
+		{"(f x) y", "f x y"},
+		{"λx.f x y", "λx.f (x y)"},
+		{"f", "f"},
+	}
+
+	p := &Parser{RightAssocApp: true}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := p.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+
+			if result.String() != tt.expectedStr {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.input, result.String(), tt.expectedStr)
+			}
+		})
+	}
+
+	// The default remains left-associative
+	result, err := (&Parser{}).Parse("f x y")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if result.String() != "f x y" {
+		t.Errorf("default Parse(%q).String() = %q, want %q", "f x y", result.String(), "f x y")
+	}
+}
+
+func TestParserConcurrent(t *testing.T) {
+	p := &Parser{RightAssocApp: true}
+	inputs := []string{"f x y", "λx.f x y", "(a b) c d", "g (h i) j"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, input := range inputs {
+				want, err := (&Parser{RightAssocApp: true}).Parse(input)
+				if err != nil {
+					t.Errorf("Parse(%q) returned error: %v", input, err)
+					return
+				}
+				for j := 0; j < 50; j++ {
+					got, err := p.Parse(input)
+					if err != nil || got.String() != want.String() {
+						t.Errorf("shared Parse(%q) = %v, %v, want %v", input, got, err, want)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParseRedundantParens(t *testing.T) {
+	tests := []struct {
+		input     string
+		canonical string
+	}{
+		{"(((x)))", "x"},
+		{"( ( x ) )", "x"},
+		{"((λx.(x)))", "λx.x"},
+		{"(λx.((x)))", "λx.x"},
+		{"λx.(λy.((x) (y)))", "λx.λy.x y"},
+		{"((f) ((x)))", "f x"},
+		{"(((f x)) y)", "f x y"},
+		{"((λx.x)) ((y))", "(λx.x) y"},
+		{"(\\x.((\\y.(y))))", "λx.λy.y"},
+		{"((_I))", "_I"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			want, err := Parse(tt.canonical)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.canonical, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.input, got, want)
+			}
+		})
+	}
+}
+
+func TestParseLambdaSymbols(t *testing.T) {
+	p := &Parser{LambdaSymbols: []rune{'λ', '\\', 'Λ', '＼'}}
+	tests := []struct {
+		input       string
+		expectedStr string
+	}{
+		{"Λx.x", "λx.x"},
+		{"＼x.＼y.x", "λx.λy.x"},
+		{"(Λx.x) (\\y.y) (λz.z)", "(λx.x) (λy.y) (λz.z)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := p.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if result.String() != tt.expectedStr {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.input, result.String(), tt.expectedStr)
+			}
+		})
+	}
+
+	// By default Λ is an ordinary letter
+	if _, err := Parse("Λx.x"); err == nil {
+		t.Error("Parse(\"Λx.x\") should fail without Λ as a lambda symbol")
+	}
+
+	// The list replaces the defaults
+	only := &Parser{LambdaSymbols: []rune{'Λ'}}
+	if _, err := only.Parse("\\x.x"); err == nil {
+		t.Error("Parse(\"\\x.x\") should fail when \\ is not listed")
+	}
+}
+
+func TestParseUnicodeIdentifiers(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedStr string
+	}{
+		{"λα.α β", "λα.α β"},
+		{"λé.é", "λé.é"},
+		{"λ名前.名前 x", "λ名前.名前 x"},
+		{"fλx.x", "f (λx.x)"},
+		{"λx.x y", "λx.x y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if result.String() != tt.expectedStr {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.input, result.String(), tt.expectedStr)
+			}
+		})
+	}
+}
+
+func TestParseUnicodeRoundTrip(t *testing.T) {
+	for _, input := range []string{"λα.α", "λα.λβ.α β", "λ名.名 名", "(λφ.φ) ψ"} {
+		t.Run(input, func(t *testing.T) {
+			term, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", input, err)
+			}
+			if term.String() != input {
+				t.Errorf("Parse(%q).String() = %q", input, term.String())
+			}
+			again, err := Parse(term.String())
+			if err != nil {
+				t.Fatalf("reparse of %q returned error: %v", term.String(), err)
+			}
+			if !reflect.DeepEqual(again, term) {
+				t.Errorf("reparse of %q = %#v, want %#v", input, again, term)
+			}
+		})
+	}
+}
+
+func TestParsePrimes(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedStr string
+	}{
+		{"λx'.x'", "λx'.x'"},
+		{"λx.λx'.λx''.x x' x''", "λx.λx'.λx''.x x' x''"},
+		{"f' x", "f' x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if result.String() != tt.expectedStr {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.input, result.String(), tt.expectedStr)
+			}
+		})
+	}
+
+	// A prime cannot start an identifier
+	if _, err := Parse("'x"); err == nil {
+		t.Error("Parse(\"'x\") should fail")
+	}
+
+	expr, err := Parse("(λx'.x') y")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if result, _ := Reduce(expr, 10); result.String() != "y" {
+		t.Errorf("(λx'.x') y reduced to %s, want y", result)
+	}
+}
+
+func TestParseMaxDepth(t *testing.T) {
+	p := &Parser{MaxDepth: 100}
+
+	deepParens := strings.Repeat("(", 100000) + "x" + strings.Repeat(")", 100000)
+	deepLambdas := strings.Repeat(`\x.`, 100000) + "x"
+	for _, input := range []string{"_999999", "_N999999", deepParens, "f " + deepParens, deepLambdas, "_98"} {
+		if _, err := p.Parse(input); !errors.Is(err, ErrTooDeep) {
+			name := input
+			if len(name) > 20 {
+				name = name[:20] + "..."
+			}
+			t.Errorf("Parse(%q) error = %v, want ErrTooDeep", name, err)
+		}
+	}
+
+	for _, input := range []string{"_97", "_PLUS _2 _3", "(((x)))"} {
+		if _, err := p.Parse(input); err != nil {
+			t.Errorf("Parse(%q): %v", input, err)
+		}
+	}
+}
+
+func TestParseInfix(t *testing.T) {
+	p := &Parser{Infix: true}
+
+	// Precedence: 2 + 3 * 4 is PLUS 2 (MULT 3 4)
+	result, err := p.Parse("2 + 3 * 4")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	want := App(PLUS, ChurchNumeral(2), App(MULT, ChurchNumeral(3), ChurchNumeral(4)))
+	if !AlphaEquivalent(result, want) {
+		t.Errorf("Parse(%q) = %s, want %s", "2 + 3 * 4", result, want)
+	}
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"10 - 3 - 2", 5},
+		{"_PRED 5 * 2", 8},
+		{"(λn.n-1) 4", 3},
+		{"(λm.λn._IF (m <= n) 1 0) 2 3", 1},
+		{"_IF (3 > 2 * 2) 1 0", 0},
+	}
+	for _, tt := range tests {
+		result, err := p.Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got, ok := ToIntPure(result, 10000); !ok || got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+
+	// Operators are not accepted by default
+	for _, input := range []string{"2 + 3", "a + b", "n - 1"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("default Parse(%q) succeeded, want an error", input)
+		}
+	}
+	if _, err := p.Parse("1 +"); err == nil {
+		t.Errorf("Parse(%q) succeeded, want an error", "1 +")
+	}
+}
+
+func TestParseKeywords(t *testing.T) {
+	p := &Parser{Keywords: true}
+
+	result, err := p.Parse("if c then a else b")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	want := App(IF, V("c"), V("a"), V("b"))
+	if !AlphaEquivalent(result, want) {
+		t.Errorf("Parse = %s, want %s", result, want)
+	}
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"if true then _1 else _2", 1},
+		{"if false then _1 else _2", 2},
+		{"if _ISZERO _0 then if false then _3 else _4 else _5", 4},
+		{"_SUCC (if _LEQ _3 _2 then _0 else _PRED _3)", 3},
+		{"(λb.if b then _7 else _8) (_NOT true)", 8},
+	}
+	for _, tt := range tests {
+		result, err := p.Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got, ok := ToIntPure(result, 10000); !ok || got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+
+	// Combined with infix operators
+	ip := &Parser{Keywords: true, Infix: true}
+	result, err = ip.Parse("(λn.if n <= 2 then n * 10 else n - 1) 2")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got, ok := ToIntPure(result, 10000); !ok || got != 20 {
+		t.Errorf("if with infix = %d, want 20", got)
+	}
+
+	for _, input := range []string{"if c then a", "if c a else b", "λif.if", "f then", "true else"} {
+		if _, err := p.Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", input)
+		}
+	}
+
+	// The words are ordinary names by default
+	result, err = Parse("λif.λthen.if then true")
+	if err != nil {
+		t.Fatalf("default Parse returned error: %v", err)
+	}
+	if result.String() != "λif.λthen.if then true" {
+		t.Errorf("default Parse = %s", result)
+	}
+}
+
+func TestParseListLiterals(t *testing.T) {
+	result, err := Parse("[]")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if result != NIL {
+		t.Errorf("Parse([]) = %s, want _NIL", result)
+	}
+
+	result, err = Parse("_MAP _SUCC [_1, _2, _3]")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	items, ok := FromChurchList(result)
+	if !ok || len(items) != 3 {
+		t.Fatalf("FromChurchList = %v, %v", items, ok)
+	}
+	for i, item := range items {
+		if got := ToInt(item); got != i+2 {
+			t.Errorf("item %d = %d, want %d", i, got, i+2)
+		}
+	}
+
+	// Nested lists, trailing commas and expressions as elements
+	result, err = Parse("[[_1], [_2, _3,], [], λx.x, _PLUS _1 _1,]")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	items, ok = FromChurchList(result)
+	if !ok || len(items) != 5 {
+		t.Fatalf("FromChurchList = %v, %v", items, ok)
+	}
+	for i, want := range []int{1, 2, 0} {
+		inner, ok := FromChurchList(items[i])
+		if !ok || len(inner) != want {
+			t.Errorf("item %d has %d elements, want %d", i, len(inner), want)
+		}
+	}
+	if got := ToInt(items[4]); got != 2 {
+		t.Errorf("item 4 = %d, want 2", got)
+	}
+
+	for _, input := range []string{"[", "[_1", "[_1 _2", "[,]", "[_1,,]", "_1]"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", input)
+		}
+	}
+}
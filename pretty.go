@@ -0,0 +1,250 @@
+package lambda
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PrintOptions controls PrettyString.
+type PrintOptions struct {
+	// Numerals prints Church numerals λf.λx.f^n x (and compact Numerals)
+	// as their decimal value.
+	Numerals bool
+
+	// CollapseLambdas prints nested abstractions λx.λy.λz.t as λxyz.t, or
+	// λx y z.t when a name is longer than one character.
+	CollapseLambdas bool
+
+	// MinimalParens omits the parentheses around an abstraction in last
+	// argument position, where it extends to the end anyway: f (λx.x)
+	// prints as f λx.x.
+	MinimalParens bool
+
+	// RoundTrip restricts the output to syntax Parse accepts, so the result
+	// parses back to an α-equivalent term: numerals print as _n constants,
+	// lambdas are never collapsed and compact numerals are written out.
+	RoundTrip bool
+}
+
+// PrettyString formats t for reading. With the zero PrintOptions it
+// prints like String, except that compact numerals are written out.
+func PrettyString(t Term, opts PrintOptions) string {
+	var sb strings.Builder
+	pp := prettyPrinter{sb: &sb, opts: opts}
+	pp.term(t, true)
+	return sb.String()
+}
+
+type prettyPrinter struct {
+	sb   *strings.Builder
+	opts PrintOptions
+}
+
+// term prints t. last reports whether t is the last thing printed in its
+// enclosing expression, so that an abstraction needs no parentheses.
+func (pp *prettyPrinter) term(t Term, last bool) {
+	t = unwrap(t)
+
+	if pp.opts.Numerals {
+		if n, ok := matchNumeral(t); ok {
+			if pp.opts.RoundTrip {
+				pp.sb.WriteByte('_')
+			}
+			pp.sb.WriteString(strconv.Itoa(n))
+			return
+		}
+	}
+
+	switch t := t.(type) {
+	case Var:
+		pp.sb.WriteString(t.Name)
+	case Abstraction:
+		pp.abstraction(t)
+	case Application:
+		pp.application(t, last)
+	case Numeral:
+		pp.term(t.Expand(), last)
+	case NumeralApply:
+		if pp.opts.RoundTrip {
+			pp.term(t.Expand(), last)
+			return
+		}
+		// λx.f^n x, as String prints it
+		fmt.Fprintf(pp.sb, "λ%s.", t.Param)
+		pp.atom(t.F, false)
+		if t.N != 1 {
+			fmt.Fprintf(pp.sb, "^%d", t.N)
+		}
+		pp.sb.WriteString(" " + t.Param)
+	}
+}
+
+func (pp *prettyPrinter) abstraction(a Abstraction) {
+	if !pp.opts.CollapseLambdas || pp.opts.RoundTrip {
+		pp.sb.WriteString("λ" + a.Param + ".")
+		pp.term(a.Body, true)
+		return
+	}
+
+	params := []string{a.Param}
+	body := unwrap(a.Body)
+	for {
+		inner, ok := body.(Abstraction)
+		if !ok {
+			break
+		}
+		if pp.opts.Numerals {
+			if _, ok := matchNumeral(inner); ok {
+				break
+			}
+		}
+		params = append(params, inner.Param)
+		body = unwrap(inner.Body)
+	}
+
+	sep := ""
+	for _, p := range params {
+		if utf8.RuneCountInString(p) > 1 {
+			sep = " "
+			break
+		}
+	}
+	pp.sb.WriteString("λ" + strings.Join(params, sep) + ".")
+	pp.term(body, true)
+}
+
+// application prints a chain of applications f a b c without the
+// parentheses implied by left associativity.
+func (pp *prettyPrinter) application(a Application, last bool) {
+	var args []Term
+	var head Term = a
+	for {
+		app, ok := unwrap(head).(Application)
+		if !ok {
+			break
+		}
+		args = append(args, app.Arg)
+		head = app.Func
+	}
+
+	pp.atom(head, false)
+	for i := len(args) - 1; i >= 0; i-- {
+		pp.sb.WriteByte(' ')
+		pp.atom(args[i], last && i == 0)
+	}
+}
+
+// atom prints t, in parentheses unless it is a variable or a numeral.
+// With MinimalParens, an abstraction printed last needs none either.
+func (pp *prettyPrinter) atom(t Term, last bool) {
+	t = unwrap(t)
+	switch t.(type) {
+	case Var:
+		pp.term(t, last)
+		return
+	case Abstraction, Numeral, NumeralApply:
+		if pp.opts.Numerals {
+			if _, ok := matchNumeral(t); ok {
+				pp.term(t, last)
+				return
+			}
+		}
+		if last && pp.opts.MinimalParens {
+			pp.term(t, last)
+			return
+		}
+	}
+	pp.sb.WriteByte('(')
+	pp.term(t, true)
+	pp.sb.WriteByte(')')
+}
+
+// StringCompact prints t like String, but merges consecutive abstractions
+// into one binder list: λx.λy.λz.x prints as λxyz.x, and as λx y z.x when
+// a name is longer than one character. Parse reads λxyz as a single
+// parameter named xyz, so the output does not always parse back.
+func StringCompact(t Term) string {
+	return PrettyString(t, PrintOptions{CollapseLambdas: true})
+}
+
+// StringTruncated prints t like String, but stops after maxLen runes and
+// appends "…" if anything was left out. The output is built as the term is
+// walked, so printing the prefix of a huge term does not allocate the whole
+// string. A multi-byte rune such as λ is never split.
+func StringTruncated(t Term, maxLen int) string {
+	w := truncWriter{left: maxLen}
+	w.term(t)
+	if w.full {
+		w.sb.WriteString("…")
+	}
+	return w.sb.String()
+}
+
+// truncWriter writes at most left more runes, setting full once it had to
+// drop one.
+type truncWriter struct {
+	sb   strings.Builder
+	left int
+	full bool
+}
+
+func (w *truncWriter) WriteString(s string) {
+	for _, r := range s {
+		if w.left <= 0 {
+			w.full = true
+			return
+		}
+		w.sb.WriteRune(r)
+		w.left--
+	}
+}
+
+// term prints t with the parentheses String uses.
+func (w *truncWriter) term(t Term) {
+	if w.full {
+		return
+	}
+	switch t := unwrap(t).(type) {
+	case Var:
+		w.WriteString(t.Name)
+	case Abstraction:
+		w.WriteString("λ" + t.Param + ".")
+		w.term(t.Body)
+	case Application:
+		_, parens := unwrap(t.Func).(Abstraction)
+		w.parenthesized(t.Func, parens)
+		w.WriteString(" ")
+		switch unwrap(t.Arg).(type) {
+		case Application, Abstraction:
+			parens = true
+		default:
+			parens = false
+		}
+		w.parenthesized(t.Arg, parens)
+	case Numeral:
+		w.WriteString(t.String())
+	case NumeralApply:
+		w.WriteString("λ" + t.Param + ".")
+		if t.N == 0 {
+			w.WriteString(t.Param)
+			return
+		}
+		w.term(t.F)
+		if t.N != 1 {
+			w.WriteString("^" + strconv.FormatUint(t.N, 10))
+		}
+		w.WriteString(" " + t.Param)
+	}
+}
+
+func (w *truncWriter) parenthesized(t Term, parens bool) {
+	if !parens {
+		w.term(t)
+		return
+	}
+	w.WriteString("(")
+	w.term(t)
+	w.WriteString(")")
+}
@@ -0,0 +1,127 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestPrettyString(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  PrintOptions
+		want  string
+	}{
+		{"λx.λy.x", PrintOptions{}, "λx.λy.x"},
+		{"f (λx.x) y", PrintOptions{}, "f (λx.x) y"},
+		{"f (g x) (λx.x)", PrintOptions{}, "f (g x) (λx.x)"},
+		{"f (g x) (λx.x)", PrintOptions{MinimalParens: true}, "f (g x) λx.x"},
+		{"(f (λx.x)) y", PrintOptions{MinimalParens: true}, "f (λx.x) y"},
+		{"λx.λy.λz.x z (y z)", PrintOptions{CollapseLambdas: true}, "λxyz.x z (y z)"},
+		{"λab.λc.ab", PrintOptions{CollapseLambdas: true}, "λab c.ab"},
+		{"_PLUS _2", PrintOptions{Numerals: true}, "(λm.λn.λf.λx.m f (n f x)) 2"},
+		{"λf.λx.f (f (f x))", PrintOptions{Numerals: true}, "3"},
+		{"λy.λf.λx.f x", PrintOptions{Numerals: true, CollapseLambdas: true}, "λy.1"},
+		{"g _2 _0", PrintOptions{Numerals: true, RoundTrip: true}, "g _2 _0"},
+		{"λx.λy.λz.x", PrintOptions{CollapseLambdas: true, RoundTrip: true}, "λx.λy.λz.x"},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.input, err)
+		}
+		if got := PrettyString(term, tt.opts); got != tt.want {
+			t.Errorf("PrettyString(%s, %+v) = %q, want %q", tt.input, tt.opts, got, tt.want)
+		}
+	}
+
+	// Compact numerals
+	if got := PrettyString(Numeral(2), PrintOptions{Numerals: true}); got != "2" {
+		t.Errorf("PrettyString(Numeral(2)) = %q, want 2", got)
+	}
+	na := NumeralApply{N: 3, Param: "x", F: Var{Name: "g"}}
+	if got := PrettyString(na, PrintOptions{}); got != "λx.g^3 x" {
+		t.Errorf("PrettyString(%s) = %q", na, got)
+	}
+}
+
+func TestPrettyStringRoundTrip(t *testing.T) {
+	opts := PrintOptions{Numerals: true, CollapseLambdas: true, MinimalParens: true, RoundTrip: true}
+	terms := []Term{
+		FACTORIAL,
+		Application{Func: Var{Name: "f"}, Arg: Abstraction{Param: "x", Body: Var{Name: "x"}}},
+		Application{Func: Application{Func: PLUS, Arg: ChurchNumeral(2)}, Arg: Numeral(3)},
+		NumeralApply{N: 2, Param: "x", F: Var{Name: "g"}},
+		Abstraction{Param: "x", Body: Application{Func: Var{Name: "x"}, Arg: Abstraction{Param: "y", Body: Application{Func: Var{Name: "y"}, Arg: Var{Name: "x"}}}}},
+	}
+
+	for _, term := range terms {
+		s := PrettyString(term, opts)
+		parsed, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", s, err)
+			continue
+		}
+		if !AlphaEquivalent(parsed, term) {
+			t.Errorf("%q parses to %s, want %s", s, parsed, term)
+		}
+	}
+}
+
+func TestStringCompact(t *testing.T) {
+	tests := []struct {
+		term Term
+		want string
+	}{
+		{K, "λxy.x"},
+		{S, "λxyz.x z (y z)"},
+		{ChurchNumeral(2), "λfx.f (f x)"},
+		{Application{Func: Var{Name: "g"}, Arg: K}, "g (λxy.x)"},
+		{Abstraction{Param: "n", Body: Application{Func: I, Arg: Var{Name: "n"}}}, "λn.(λx.x) n"},
+		{Abstraction{Param: "acc", Body: Abstraction{Param: "x", Body: Var{Name: "acc"}}}, "λacc x.acc"},
+	}
+
+	for _, tt := range tests {
+		if got := StringCompact(tt.term); got != tt.want {
+			t.Errorf("StringCompact(%s) = %q, want %q", tt.term, got, tt.want)
+		}
+	}
+
+	// String itself is unchanged
+	if K.String() != "λx.λy.x" {
+		t.Errorf("K.String() = %q, want λx.λy.x", K.String())
+	}
+}
+
+func TestStringTruncated(t *testing.T) {
+	terms := []Term{
+		Var{Name: "x"},
+		FACTORIAL,
+		Application{Func: K, Arg: Application{Func: Var{Name: "f"}, Arg: I}},
+		Application{Func: Numeral(3), Arg: Var{Name: "f"}},
+		NumeralApply{N: 0, Param: "x", F: Var{Name: "f"}},
+		NumeralApply{N: 1, Param: "x", F: Var{Name: "f"}},
+		NumeralApply{N: 4, Param: "x", F: Application{Func: Var{Name: "g"}, Arg: Var{Name: "y"}}},
+		Application{Func: NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}}, Arg: NumeralApply{N: 2, Param: "x", F: Var{Name: "f"}}},
+	}
+
+	for _, term := range terms {
+		full := term.String()
+		runes := []rune(full)
+		if got := StringTruncated(term, len(runes)); got != full {
+			t.Errorf("StringTruncated(%s, %d) = %q", full, len(runes), got)
+		}
+		for n := 0; n < len(runes); n++ {
+			want := string(runes[:n]) + "…"
+			if got := StringTruncated(term, n); got != want {
+				t.Errorf("StringTruncated(%s, %d) = %q, want %q", full, n, got, want)
+				break
+			}
+		}
+	}
+
+	// A huge term is cut without printing it
+	huge := ChurchNumeral(1 << 20)
+	if got := StringTruncated(huge, 10); got != "λf.λx.f (f…" {
+		t.Errorf("StringTruncated(huge, 10) = %q", got)
+	}
+}
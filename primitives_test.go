@@ -18,13 +18,7 @@ func TestEQ(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			result := Application{
-				Func: Application{
-					Func: EQ,
-					Arg:  ChurchNumeral(tt.m),
-				},
-				Arg: ChurchNumeral(tt.n),
-			}
+			result := Apply(EQ, ChurchNumeral(tt.m), ChurchNumeral(tt.n))
 
 			reduced, _ := Reduce(result, 5000)
 			got := ToBool(reduced)
@@ -36,6 +30,39 @@ func TestEQ(t *testing.T) {
 	}
 }
 
+func TestBOOLTONUM(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"_B2N _TRUE", 1},
+		{"_B2N _FALSE", 0},
+		{"_B2N (_ISZERO _0)", 1},
+		{"_PLUS (_B2N _TRUE) (_B2N _TRUE)", 2},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		reduced, _ := Reduce(expr, 1000)
+		if got := ToInt(reduced); got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNUMTOBOOL(t *testing.T) {
+	for n, want := range []bool{false, true, true, true} {
+		result := Application{Func: NUMTOBOOL, Arg: ChurchNumeral(n)}
+		reduced, _ := Reduce(result, 1000)
+		if got := ToBool(reduced); got != want {
+			t.Errorf("N2B %d = %v, want %v", n, got, want)
+		}
+	}
+}
+
 func TestMAX(t *testing.T) {
 	tests := []struct {
 		a, b int
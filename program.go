@@ -0,0 +1,173 @@
+package lambda
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Programs
+//
+// A program is a sequence of definitions name = expr, separated by newlines
+// or semicolons, optionally followed by a final expression. Definitions may
+// refer to each other by name, in any order; a name is replaced by the term
+// it defines. Lines starting with # are comments.
+//
+//	double = λn._PLUS n n
+//	four = double _2
+//	double four
+
+// Program is the result of ParseProgram.
+type Program struct {
+	Names []string        // Defined names, in order of definition
+	Defs  map[string]Term // Definitions, with references to other definitions expanded
+	Main  Term            // Final expression, or nil if the program has none
+}
+
+// CycleError reports definitions that refer to themselves, directly or
+// through other definitions. Recursion must be written explicitly with a
+// fixed-point combinator such as _Y instead.
+type CycleError struct {
+	Cycle []string // The names along the cycle, the first repeated at the end
+}
+
+func (e *CycleError) Error() string {
+	return "circular definition: " + strings.Join(e.Cycle, " -> ")
+}
+
+// ParseProgram parses a program and expands the references between its
+// definitions. It fails with a *CycleError if definitions refer to each
+// other in a cycle, and with an error naming the line for parse errors and
+// duplicate definitions.
+func ParseProgram(src string) (*Program, error) {
+	prog := &Program{Defs: make(map[string]Term)}
+	bodies := make(map[string]Term)
+	var main Term
+
+	lineNo := 0
+	for _, line := range strings.Split(src, "\n") {
+		lineNo++
+		for _, stmt := range splitStatements(line) {
+			stmt = strings.TrimSpace(stmt)
+			if stmt == "" || strings.HasPrefix(stmt, "#") {
+				continue
+			}
+			if main != nil {
+				return nil, fmt.Errorf("line %d: statement after the final expression", lineNo)
+			}
+
+			name, expr, isDef := strings.Cut(stmt, "=")
+			if strings.Contains(name, "{") {
+				// The = of a where clause in the final expression
+				isDef = false
+			}
+			if !isDef {
+				term, err := Parse(stmt)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				main = term
+				continue
+			}
+
+			name = strings.TrimSpace(name)
+			if v, err := Parse(name); err != nil || v != (Var{Name: name}) {
+				return nil, fmt.Errorf("line %d: invalid name %q", lineNo, name)
+			}
+			if _, dup := bodies[name]; dup {
+				return nil, fmt.Errorf("line %d: %s is already defined", lineNo, name)
+			}
+			term, err := Parse(expr)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			bodies[name] = term
+			prog.Names = append(prog.Names, name)
+		}
+	}
+
+	r := &programResolver{bodies: bodies, defs: prog.Defs, visiting: make(map[string]bool)}
+	for _, name := range prog.Names {
+		if _, err := r.resolve(name); err != nil {
+			return nil, err
+		}
+	}
+	if main != nil {
+		prog.Main = r.expand(main)
+	}
+	return prog, nil
+}
+
+// splitStatements splits a line at the semicolons that are not inside the
+// braces of a where clause.
+func splitStatements(line string) []string {
+	var stmts []string
+	depth, start := 0, 0
+	for i, ch := range line {
+		switch ch {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ';':
+			if depth == 0 {
+				stmts = append(stmts, line[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(stmts, line[start:])
+}
+
+// programResolver expands the definitions of a program in dependency
+// order, detecting cycles.
+type programResolver struct {
+	bodies   map[string]Term // Definitions as written
+	defs     map[string]Term // Definitions already expanded
+	visiting map[string]bool // Definitions being expanded
+	path     []string        // Definitions being expanded, in order
+}
+
+// resolve returns the expanded definition of name.
+func (r *programResolver) resolve(name string) (Term, error) {
+	if t, ok := r.defs[name]; ok {
+		return t, nil
+	}
+	if r.visiting[name] {
+		// Report the cycle starting where name was first entered
+		for i, n := range r.path {
+			if n == name {
+				cycle := append(append([]string{}, r.path[i:]...), name)
+				return nil, &CycleError{Cycle: cycle}
+			}
+		}
+	}
+
+	r.visiting[name] = true
+	r.path = append(r.path, name)
+	body := r.bodies[name]
+	for _, dep := range FreeVarNames(body) {
+		if _, ok := r.bodies[dep]; ok {
+			if _, err := r.resolve(dep); err != nil {
+				return nil, err
+			}
+		}
+	}
+	r.path = r.path[:len(r.path)-1]
+	delete(r.visiting, name)
+
+	t := r.expand(body)
+	r.defs[name] = t
+	return t, nil
+}
+
+// expand replaces the references to definitions in t, which must all have
+// been resolved already.
+func (r *programResolver) expand(t Term) Term {
+	subst := make(map[string]Term)
+	for _, name := range FreeVarNames(t) {
+		if def, ok := r.defs[name]; ok {
+			subst[name] = def
+		}
+	}
+	return SubstituteAll(t, subst)
+}
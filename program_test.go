@@ -0,0 +1,86 @@
+package lambda
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseProgram(t *testing.T) {
+	prog, err := ParseProgram(`
+		# Definitions may come in any order
+		four = double _2
+		double = λn._PLUS n n
+		double four
+	`)
+	if err != nil {
+		t.Fatalf("ParseProgram error: %v", err)
+	}
+	if !reflect.DeepEqual(prog.Names, []string{"four", "double"}) {
+		t.Errorf("Names = %v", prog.Names)
+	}
+	if got := ToInt(prog.Defs["four"]); got != 4 {
+		t.Errorf("four = %d, want 4", got)
+	}
+	if got := ToInt(prog.Main); got != 8 {
+		t.Errorf("main = %d, want 8", got)
+	}
+
+	// Semicolons separate statements too, and the final expression is optional
+	prog, err = ParseProgram("id = λx.x; k = λx.λy.x")
+	if err != nil {
+		t.Fatalf("ParseProgram error: %v", err)
+	}
+	if prog.Main != nil || len(prog.Defs) != 2 {
+		t.Errorf("got %d definitions and main %v", len(prog.Defs), prog.Main)
+	}
+}
+
+func TestParseProgramCycles(t *testing.T) {
+	tests := []struct {
+		src   string
+		cycle []string
+	}{
+		{"A = B\nB = A", []string{"A", "B", "A"}},
+		{"f = λx.f x", []string{"f", "f"}},
+		{"a = b; b = c; c = λx.a x; a", []string{"a", "b", "c", "a"}},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseProgram(tt.src)
+		var cycleErr *CycleError
+		if !errors.As(err, &cycleErr) {
+			t.Errorf("ParseProgram(%q) error = %v, want a CycleError", tt.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(cycleErr.Cycle, tt.cycle) {
+			t.Errorf("ParseProgram(%q) cycle = %v, want %v", tt.src, cycleErr.Cycle, tt.cycle)
+		}
+	}
+
+	// Recursion through _Y is explicit and allowed
+	prog, err := ParseProgram(`
+		fact = _Y (λf.λn._IF (_ISZERO n) _1 (_MULT n (f (_PRED n))))
+		fact _3
+	`)
+	if err != nil {
+		t.Fatalf("ParseProgram error: %v", err)
+	}
+	if result, _ := Reduce(prog.Main, 10000); ToInt(result) != 6 {
+		t.Errorf("fact 3 = %s, want 6", result)
+	}
+}
+
+func TestParseProgramErrors(t *testing.T) {
+	for _, src := range []string{
+		"x = λy.(y",
+		"x = λy.y\nx = λz.z",
+		"f x = x",
+		"_K = λx.x",
+		"x\ny",
+	} {
+		if _, err := ParseProgram(src); err == nil {
+			t.Errorf("ParseProgram(%q) should fail", src)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package lambda
+
+import (
+	"fmt"
+)
+
+// SELF_EVAL := Y (λe.λm.m (λx.x) (λm.λn.(e m) (e n)) (λm.λv.e (m v)))
+// Mogensen's self-interpreter: SELF_EVAL ⌜M⌝ reduces to the value of M for
+// any term quoted with Quote. Interpretation is expensive: every node of the
+// quoted term costs several extra β-steps on top of evaluating M itself.
+var SELF_EVAL = MakeLazyScript(`
+	_Y (λe.λm.
+		m (λx.x)
+			(λm.λn.(e m) (e n))
+			(λm.λv.e (m v)))
+`)
+
+// Quote returns a Church-style encoding of the structure of t, using
+// Mogensen's higher-order representation:
+//
+//	⌜x⌝   = λa.λb.λc.a x
+//	⌜M N⌝ = λa.λb.λc.b ⌜M⌝ ⌜N⌝
+//	⌜λx.M⌝ = λa.λb.λc.c (λx.⌜M⌝)
+//
+// Variables stand for themselves, so a quoted abstraction is itself a
+// function from variables to quoted bodies. The names a, b and c are chosen
+// fresh so they never capture variables of t.
+func Quote(t Term) Term {
+	names := make(map[string]bool)
+	varNames(t, names)
+	q := quoter{}
+	q.a = freshVar("a", names)
+	names[q.a] = true
+	q.b = freshVar("b", names)
+	names[q.b] = true
+	q.c = freshVar("c", names)
+	return q.quote(t)
+}
+
+// quoter holds the binder names used by the three quotation cases.
+type quoter struct {
+	a, b, c string
+}
+
+func (q quoter) wrap(body Term) Term {
+	return Abstraction{Param: q.a, Body: Abstraction{Param: q.b, Body: Abstraction{Param: q.c, Body: body}}}
+}
+
+func (q quoter) quote(t Term) Term {
+	if na, ok := asAbstraction(t); ok {
+		return q.wrap(Application{
+			Func: Var{Name: q.c},
+			Arg:  Abstraction{Param: na.Param, Body: q.quote(na.Body)},
+		})
+	}
+	switch term := unwrap(t).(type) {
+	case Var:
+		return q.wrap(Application{Func: Var{Name: q.a}, Arg: term})
+	case Application:
+		return q.wrap(Application{
+			Func: Application{Func: Var{Name: q.b}, Arg: q.quote(term.Func)},
+			Arg:  q.quote(term.Arg),
+		})
+	}
+	return t
+}
+
+// Unquote decodes a term produced by Quote back into the term it encodes.
+// The input is reduced to normal form first (at most limit steps; a limit
+// of 0 or less uses DefaultStepLimit), so quotations built by reduction,
+// not only by Quote itself, are accepted.
+func Unquote(t Term, limit int) (Term, error) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	nf, ok := normalForm(t, limit)
+	if !ok {
+		return nil, ErrNotNormalized
+	}
+	return unquote(nf)
+}
+
+func unquote(t Term) (Term, error) {
+	a, ok := asAbstraction(t)
+	if !ok {
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+	b, ok := asAbstraction(a.Body)
+	if !ok {
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+	c, ok := asAbstraction(b.Body)
+	if !ok {
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+
+	app, ok := unwrap(c.Body).(Application)
+	if !ok {
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+
+	// The head is either a variable applied to one argument (a, c) or to
+	// two arguments (b). Inner binders shadow outer ones.
+	if head, ok := unwrap(app.Func).(Var); ok {
+		switch head.Name {
+		case c.Param:
+			fn, ok := asAbstraction(app.Arg)
+			if !ok {
+				return nil, fmt.Errorf("quoted abstraction body is not a function: %s", app.Arg)
+			}
+			body, err := unquote(fn.Body)
+			if err != nil {
+				return nil, err
+			}
+			return Abstraction{Param: fn.Param, Body: body}, nil
+		case b.Param:
+			return nil, fmt.Errorf("quoted application is missing its argument: %s", t)
+		case a.Param:
+			v, ok := unwrap(app.Arg).(Var)
+			if !ok {
+				return nil, fmt.Errorf("quoted variable is not a variable: %s", app.Arg)
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+
+	inner, ok := unwrap(app.Func).(Application)
+	if !ok {
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+	if head, ok := unwrap(inner.Func).(Var); !ok || head.Name != b.Param || head.Name == c.Param {
+		return nil, fmt.Errorf("not a quoted term: %s", t)
+	}
+	fn, err := unquote(inner.Arg)
+	if err != nil {
+		return nil, err
+	}
+	arg, err := unquote(app.Arg)
+	if err != nil {
+		return nil, err
+	}
+	return Application{Func: fn, Arg: arg}, nil
+}
@@ -0,0 +1,79 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestQuoteUnquote(t *testing.T) {
+	tests := []struct {
+		name string
+		term Term
+	}{
+		{"K", K},
+		{"S", S},
+		{"free variable", Var{Name: "x"}},
+		{"application", Application{Func: Var{Name: "f"}, Arg: Var{Name: "x"}}},
+		{"numeral", Numeral(2)},
+		{"binder names clash", MakeLazyScript(`λa.λb.λc.c b a`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unquote(Quote(tt.term), 0)
+			if err != nil {
+				t.Fatalf("Unquote(Quote(%s)) error: %v", tt.term, err)
+			}
+			if !AlphaEquivalent(got, tt.term) {
+				t.Errorf("Unquote(Quote(%s)) = %s", tt.term, got)
+			}
+		})
+	}
+}
+
+func TestQuoteShape(t *testing.T) {
+	// ⌜x⌝ = λa.λb.λc.a x
+	if got := Quote(Var{Name: "x"}).String(); got != "λa.λb.λc.a x" {
+		t.Errorf("Quote(x) = %s", got)
+	}
+	// ⌜λx.x⌝ = λa.λb.λc.c (λx.λa.λb.λc.a x)
+	if got := Quote(I).String(); got != "λa.λb.λc.c (λx.λa.λb.λc.a x)" {
+		t.Errorf("Quote(I) = %s", got)
+	}
+}
+
+func TestUnquoteInvalid(t *testing.T) {
+	for _, term := range []Term{I, K, Var{Name: "x"}, ChurchNumeral(3)} {
+		if got, err := Unquote(term, 0); err == nil {
+			t.Errorf("Unquote(%s) = %s, want error", term, got)
+		}
+	}
+	if _, err := Unquote(OMEGA, 100); err != ErrNotNormalized {
+		t.Errorf("Unquote(OMEGA) error = %v, want ErrNotNormalized", err)
+	}
+}
+
+func TestSelfEval(t *testing.T) {
+	// _EVAL ⌜_PLUS _2 _3⌝ evaluates the quoted addition
+	expr, err := Parse("_PLUS _2 _3")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	result, steps := Reduce(Application{Func: SELF_EVAL, Arg: Quote(expr)}, 5000)
+	got := ToInt(result)
+	t.Logf("_EVAL ⌜_PLUS _2 _3⌝ = %d in %d steps", got, steps)
+	if got != 5 {
+		t.Errorf("_EVAL ⌜_PLUS _2 _3⌝ = %d, want 5", got)
+	}
+}
+
+func TestSelfEvalParsed(t *testing.T) {
+	// The interpreter is also reachable from the parser as _EVAL
+	expr, err := Parse("_EVAL q")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	expr = expr.Substitute("q", Quote(Application{Func: I, Arg: Var{Name: "y"}}))
+
+	AssertEquivalent(t, expr, Var{Name: "y"}, 1000)
+}
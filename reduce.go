@@ -0,0 +1,443 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ReduceSampledTrace reduces obj like Reduce, recording the term every
+// everyN steps. The initial and final terms are always included, so a
+// reduction of s steps yields about s/everyN+2 terms.
+// If limit is 0 or negative, DefaultStepLimit is used; if everyN is
+// 0 or negative, every step is recorded.
+func ReduceSampledTrace(obj Term, limit int, everyN int) []Term {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	if everyN <= 0 {
+		everyN = 1
+	}
+
+	trace := []Term{obj}
+	steps := 0
+	for steps < limit {
+		reduced, didReduce := obj.BetaReduce()
+		if !didReduce {
+			break
+		}
+		obj = reduced
+		steps++
+		if steps%everyN == 0 {
+			trace = append(trace, obj)
+		}
+	}
+
+	if steps%everyN != 0 {
+		trace = append(trace, obj)
+	}
+	return trace
+}
+
+// Normalize computes the βη-normal form of obj: it β-reduces it to normal
+// form in at most limit steps, then η-reduces the result. It reports false,
+// returning the partially reduced term, if no β-normal form was reached.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func Normalize(obj Term, limit int) (Term, bool) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	nf, ok := normalForm(obj, limit)
+	if !ok {
+		return nf, false
+	}
+	// η-reducing a β-normal form keeps it β-normal
+	for {
+		converted, didConvert := nf.EtaConvert()
+		if !didConvert {
+			return nf, true
+		}
+		nf = converted
+	}
+}
+
+// ErrMemLimit is returned by ReduceMemLimit when the term grows past the
+// allowed size.
+var ErrMemLimit = errors.New("memory limit exceeded")
+
+// termNodeBytes is a rough estimate of the memory used by one term node.
+const termNodeBytes = 32
+
+// ReduceMemLimit reduces obj like Reduce, but aborts with ErrMemLimit as
+// soon as the term would take more than maxBytes of memory, estimated from
+// its Size. This protects against terms that explode in size long before
+// the step limit is reached. The last term within the limit is returned
+// along with the number of steps performed.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceMemLimit(obj Term, limit int, maxBytes int64) (Term, int, error) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	if int64(Size(obj))*termNodeBytes > maxBytes {
+		return obj, 0, ErrMemLimit
+	}
+
+	steps := 0
+	for steps < limit {
+		reduced, didReduce := obj.BetaReduce()
+		if !didReduce {
+			break
+		}
+		if int64(Size(reduced))*termNodeBytes > maxBytes {
+			return obj, steps, ErrMemLimit
+		}
+		obj = reduced
+		steps++
+	}
+	return obj, steps, nil
+}
+
+// ErrSubstBudget is returned by ReduceSubstBudget when the substitution
+// budget is exhausted.
+var ErrSubstBudget = errors.New("substitution budget exceeded")
+
+// ReduceSubstBudget reduces obj to normal form like Reduce, but bounds the
+// work by the number of term nodes copied by substitution rather than by the
+// number of steps: contracting (λx.t) s costs the size of s times the number
+// of occurrences of x in t, so a step duplicating a large argument is
+// expensive while an erasing step is free. Expanding a compact numeral costs
+// the size of the term it builds.
+//
+// It returns the reduced term and the number of nodes substituted. If the
+// next step would take the total past maxSubstNodes, the term reached so far
+// is returned with ErrSubstBudget.
+func ReduceSubstBudget(obj Term, maxSubstNodes int) (Term, int, error) {
+	used := 0
+	for {
+		cost, ok := substCost(obj)
+		if !ok {
+			return obj, used, nil
+		}
+		if used+cost > maxSubstNodes {
+			return obj, used, ErrSubstBudget
+		}
+		reduced, _ := obj.BetaReduce()
+		obj = reduced
+		used += cost
+	}
+}
+
+// substCost returns the number of nodes the next BetaReduce step on t will
+// substitute, visiting redexes in the same order as BetaReduce. It reports
+// false if t is in normal form.
+func substCost(t Term) (int, bool) {
+	switch t := t.(type) {
+	case *LazyScript:
+		return substCost(t.parse())
+	case Abstraction:
+		return substCost(t.Body)
+	case Application:
+		switch f := unwrap(t.Func).(type) {
+		case Abstraction:
+			return FreeVarCounts(f.Body)[f.Param] * Size(t.Arg), true
+		case Numeral, NumeralApply:
+			result, _ := t.contract()
+			return Size(result), true
+		}
+		if cost, ok := substCost(t.Func); ok {
+			return cost, true
+		}
+		return substCost(t.Arg)
+	case NumeralApply:
+		return substCost(t.F)
+	}
+	return 0, false
+}
+
+// SuggestLimit heuristically recommends a step limit for reducing t with
+// Reduce. It starts from the size of the term and raises the limit for
+// patterns known to need many steps: multiplication, exponentiation, and
+// self-application as used by Y and other fixed-point combinators. The
+// suggestion is only a starting point; terms that do not normalize never
+// fit any limit.
+func SuggestLimit(t Term) int {
+	limit := 100 + 10*Size(t)
+
+	if Contains(t, MULT) {
+		limit *= 10
+	}
+	if Contains(t, POW) {
+		limit *= 100
+	}
+	if _, _, ok := FindSubterm(t, isSelfApplication); ok {
+		limit *= 100
+	}
+
+	const maxLimit = 10000000
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return limit
+}
+
+// isSelfApplication reports whether t is of the form x x.
+func isSelfApplication(t Term) bool {
+	app, ok := t.(Application)
+	if !ok {
+		return false
+	}
+	f, ok := unwrap(app.Func).(Var)
+	if !ok {
+		return false
+	}
+	x, ok := unwrap(app.Arg).(Var)
+	return ok && f.Name == x.Name
+}
+
+// SizeProfile reduces obj like Reduce and returns the Size of the term
+// before the first step and after each step, so its length is the number
+// of steps plus one. Plotted, it shows how a reduction grows the term and
+// collapses it again.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func SizeProfile(obj Term, limit int) []int {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	profile := []int{Size(obj)}
+	for len(profile) <= limit {
+		reduced, didReduce := obj.BetaReduce()
+		if !didReduce {
+			break
+		}
+		obj = reduced
+		profile = append(profile, Size(obj))
+	}
+	return profile
+}
+
+// ReduceContext reduces obj like Reduce, but also stops when ctx is done,
+// returning the partially reduced term, the number of steps performed and
+// ctx.Err(). The context is checked before each step, so a single step
+// that takes very long is not interrupted.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceContext(ctx context.Context, obj Term, limit int) (Term, int, error) {
+	return reduceGuarded(ctx, obj, limit, 0)
+}
+
+// ReduceDepthLimit reduces obj like Reduce, but aborts with ErrTooDeep as
+// soon as a step would make the term deeper than maxDepth, as measured by
+// Depth. Reduction recurses over the term, so this keeps terms that grow
+// very deep, like large numerals computed by MULT or POW, from overflowing
+// the stack. The last term within the limit is returned along with the
+// number of steps performed; obj itself is not checked.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceDepthLimit(obj Term, limit int, maxDepth int) (Term, int, error) {
+	return reduceGuarded(context.Background(), obj, limit, maxDepth)
+}
+
+// reduceGuarded reduces obj until ctx is done or, if maxDepth is positive,
+// the term would get deeper than maxDepth.
+func reduceGuarded(ctx context.Context, obj Term, limit int, maxDepth int) (Term, int, error) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	steps := 0
+	for steps < limit {
+		if err := ctx.Err(); err != nil {
+			return obj, steps, err
+		}
+		reduced, didReduce := obj.BetaReduce()
+		if !didReduce {
+			break
+		}
+		if maxDepth > 0 && Depth(reduced) > maxDepth {
+			return obj, steps, ErrTooDeep
+		}
+		obj = reduced
+		steps++
+	}
+	return obj, steps, nil
+}
+
+// CompareBranchSkip measures how much normal-order reduction saves by
+// skipping the branches a conditional discards. It reduces obj with Reduce,
+// which contracts the leftmost outermost redex and so never reduces the
+// untaken branch of IF, and again reducing every argument to normal form
+// before passing it to its function, as an applicative-order evaluator
+// does. Both step counts are returned, each capped at limit; terms relying
+// on lazy branches for recursion, like those built with Y, usually reach
+// the limit without skipping.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func CompareBranchSkip(obj Term, limit int) (withSkip, withoutSkip int) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	_, withSkip = Reduce(obj, limit)
+	_, withoutSkip = ReduceWithStrategy(obj, limit, ApplicativeOrder)
+	return withSkip, withoutSkip
+}
+
+// Strategy selects the redex contracted at each reduction step.
+type Strategy int
+
+const (
+	// NormalOrder contracts the leftmost outermost redex, as Reduce does.
+	// It reaches the normal form whenever the term has one.
+	NormalOrder Strategy = iota
+
+	// ApplicativeOrder contracts the leftmost innermost redex, reducing
+	// arguments to normal form before passing them, like a call-by-value
+	// evaluator. It reaches the same normal form when it terminates, but
+	// diverges on terms such as (λx.λy.y) Ω whose argument has none.
+	ApplicativeOrder
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case NormalOrder:
+		return "normal"
+	case ApplicativeOrder:
+		return "applicative"
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// ParseStrategy returns the strategy named name, "normal" or
+// "applicative" as printed by String.
+func ParseStrategy(name string) (Strategy, error) {
+	switch name {
+	case "normal":
+		return NormalOrder, nil
+	case "applicative":
+		return ApplicativeOrder, nil
+	}
+	return 0, fmt.Errorf("invalid strategy %q (must be: normal, applicative)", name)
+}
+
+// ReduceWithStrategy reduces obj like Reduce, contracting the redexes in
+// the order given by strategy. It returns the reduced term and the number
+// of steps performed.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceWithStrategy(obj Term, limit int, strategy Strategy) (Term, int) {
+	if strategy != ApplicativeOrder {
+		return Reduce(obj, limit)
+	}
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	steps := 0
+	for steps < limit {
+		reduced, didReduce := innermostStep(obj)
+		if !didReduce {
+			break
+		}
+		obj = reduced
+		steps++
+	}
+	return obj, steps
+}
+
+// innermostStep performs one step of leftmost innermost reduction: a redex
+// is only contracted once its function and argument are in normal form.
+func innermostStep(t Term) (Term, bool) {
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		if body, ok := innermostStep(t.Body); ok {
+			return Abstraction{Param: t.Param, Body: body}, true
+		}
+	case Application:
+		if f, ok := innermostStep(t.Func); ok {
+			return Application{Func: f, Arg: t.Arg}, true
+		}
+		if a, ok := innermostStep(t.Arg); ok {
+			return Application{Func: t.Func, Arg: a}, true
+		}
+		return t.contract()
+	case NumeralApply:
+		if f, ok := innermostStep(t.F); ok {
+			return NumeralApply{N: t.N, Param: t.Param, F: f}, true
+		}
+	}
+	return nil, false
+}
+
+// derivationWidth is the number of runes of a redex shown in a derivation
+const derivationWidth = 60
+
+// ReduceDerivation reduces obj to βη-normal form like Normalize, and
+// returns the derivation as one line per step naming the rule applied and
+// the redex it contracted, such as "β: (λx.x) y". The β-steps come first,
+// in normal order, followed by the η-steps of the β-normal form. Redexes
+// longer than 60 runes are truncated with "…". At most limit steps are
+// recorded.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceDerivation(obj Term, limit int) []string {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	var lines []string
+	for len(lines) < limit {
+		redex, ok := betaRedex(obj)
+		if !ok {
+			break
+		}
+		lines = append(lines, "β: "+StringTruncated(redex, derivationWidth))
+		obj, _ = obj.BetaReduce()
+	}
+	for len(lines) < limit {
+		redex, ok := etaRedex(obj)
+		if !ok {
+			break
+		}
+		lines = append(lines, "η: "+StringTruncated(redex, derivationWidth))
+		obj, _ = obj.EtaConvert()
+	}
+	return lines
+}
+
+// betaRedex returns the redex BetaReduce contracts in t.
+func betaRedex(t Term) (Term, bool) {
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		return betaRedex(t.Body)
+	case Application:
+		if _, ok := t.contract(); ok {
+			return t, true
+		}
+		if redex, ok := betaRedex(t.Func); ok {
+			return redex, true
+		}
+		return betaRedex(t.Arg)
+	case NumeralApply:
+		return betaRedex(t.F)
+	}
+	return nil, false
+}
+
+// etaRedex returns the redex EtaConvert contracts in t.
+func etaRedex(t Term) (Term, bool) {
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		if app, ok := t.Body.(Application); ok {
+			if v, ok := app.Arg.(Var); ok && v.Name == t.Param && !app.Func.FreeVars()[t.Param] {
+				return t, true
+			}
+		}
+		return etaRedex(t.Body)
+	case Application:
+		if redex, ok := etaRedex(t.Func); ok {
+			return redex, true
+		}
+		return etaRedex(t.Arg)
+	case NumeralApply:
+		if t.N == 1 && !t.F.FreeVars()[t.Param] {
+			return t, true
+		}
+	}
+	return nil, false
+}
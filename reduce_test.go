@@ -0,0 +1,295 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestReduceSampledTrace(t *testing.T) {
+	// SUB 5 2 takes 106 steps
+	term := Application{Func: Application{Func: SUB, Arg: ChurchNumeral(5)}, Arg: ChurchNumeral(2)}
+	final, steps := Reduce(term, 1000)
+	if steps != 106 {
+		t.Fatalf("SUB 5 2 took %d steps, test expects 106", steps)
+	}
+
+	trace := ReduceSampledTrace(term, 1000, 10)
+	// initial term, steps 10..100, final term
+	if len(trace) != 12 {
+		t.Fatalf("got %d entries, want 12", len(trace))
+	}
+	if trace[0] != Term(term) {
+		t.Errorf("first entry = %s, want the initial term", trace[0])
+	}
+	if trace[len(trace)-1].String() != final.String() {
+		t.Errorf("last entry = %s, want %s", trace[len(trace)-1], final)
+	}
+
+	want, _ := Reduce(term, 10)
+	if trace[1].String() != want.String() {
+		t.Errorf("second entry = %s, want the term after 10 steps %s", trace[1], want)
+	}
+}
+
+func TestReduceSampledTraceEndpoints(t *testing.T) {
+	// Final step already sampled: not recorded twice
+	term := Application{Func: I, Arg: Var{Name: "x"}}
+	trace := ReduceSampledTrace(term, 100, 1)
+	if len(trace) != 2 || trace[1].String() != "x" {
+		t.Errorf("trace = %v, want [(λx.x) x, x]", trace)
+	}
+
+	// Normal form: just the term itself
+	trace = ReduceSampledTrace(Var{Name: "x"}, 100, 10)
+	if len(trace) != 1 {
+		t.Errorf("trace of a normal form has %d entries, want 1", len(trace))
+	}
+}
+
+func TestReduceMemLimit(t *testing.T) {
+	// A light reduction completes
+	expr, _ := Parse("_PLUS _2 _3")
+	result, steps, err := ReduceMemLimit(expr, 1000, 10000)
+	if err != nil {
+		t.Fatalf("PLUS 2 3: %v", err)
+	}
+	if got := ToInt(result); got != 5 {
+		t.Errorf("PLUS 2 3 = %d in %d steps, want 5", got, steps)
+	}
+
+	// (λx.x x x) (λx.x x x) grows with every step
+	expr, _ = Parse("(λx.x x x) (λx.x x x)")
+	result, steps, err = ReduceMemLimit(expr, 1000, 10000)
+	if !errors.Is(err, ErrMemLimit) {
+		t.Fatalf("err = %v, want ErrMemLimit", err)
+	}
+	if steps == 0 || steps >= 1000 {
+		t.Errorf("aborted after %d steps", steps)
+	}
+	if size := int64(Size(result)) * termNodeBytes; size > 10000 {
+		t.Errorf("returned term takes %d bytes, over the limit", size)
+	}
+}
+
+func TestReduceSubstBudget(t *testing.T) {
+	// (λx.x) y substitutes a single node
+	expr, _ := Parse("(λx.x) y")
+	result, used, err := ReduceSubstBudget(expr, 5)
+	if err != nil {
+		t.Fatalf("(λx.x) y: %v", err)
+	}
+	if result.String() != "y" || used != 1 {
+		t.Errorf("(λx.x) y = %s using %d nodes, want y using 1", result, used)
+	}
+
+	// The first step already copies PLUS 2 2 twice
+	expr, _ = Parse("(λx.x x) (_PLUS _2 _2)")
+	result, used, err = ReduceSubstBudget(expr, 20)
+	if !errors.Is(err, ErrSubstBudget) {
+		t.Fatalf("err = %v, want ErrSubstBudget", err)
+	}
+	if used != 0 || result != expr {
+		t.Errorf("aborted with %s after %d nodes, want the input unchanged", result, used)
+	}
+
+	// With enough budget it computes the same normal form as Reduce
+	expr, _ = Parse("_PLUS _2 _3")
+	result, _, err = ReduceSubstBudget(expr, 10000)
+	if err != nil {
+		t.Fatalf("PLUS 2 3: %v", err)
+	}
+	if got := ToInt(result); got != 5 {
+		t.Errorf("PLUS 2 3 = %d, want 5", got)
+	}
+}
+
+func TestSuggestLimit(t *testing.T) {
+	plus, _ := Parse("_PLUS _2 _3")
+	small := SuggestLimit(plus)
+	if small > 1000 {
+		t.Errorf("SuggestLimit(PLUS 2 3) = %d, want at most 1000", small)
+	}
+	// The suggestion must be enough
+	if _, steps := Reduce(plus, small); steps >= small {
+		t.Errorf("PLUS 2 3 did not normalize within %d steps", small)
+	}
+
+	fact, _ := Parse("_FACTORIAL _3")
+	large := SuggestLimit(fact)
+	if large < 100*small {
+		t.Errorf("SuggestLimit(FACTORIAL 3) = %d, want much more than %d", large, small)
+	}
+	if _, steps := Reduce(fact, large); steps >= large {
+		t.Errorf("FACTORIAL 3 did not normalize within %d steps", large)
+	}
+}
+
+func TestSizeProfile(t *testing.T) {
+	expr, _ := Parse("_MULT _2 _3")
+	profile := SizeProfile(expr, 1000)
+	_, steps := Reduce(expr, 1000)
+	if len(profile) != steps+1 {
+		t.Fatalf("len(profile) = %d, want %d", len(profile), steps+1)
+	}
+	t.Logf("profile: %v", profile)
+
+	peak := 0
+	for _, size := range profile {
+		if size > peak {
+			peak = size
+		}
+	}
+	first, last := profile[0], profile[len(profile)-1]
+	if want := Size(ChurchNumeral(6)); last != want {
+		t.Errorf("final size = %d, want %d", last, want)
+	}
+	if peak <= first || peak <= last {
+		t.Errorf("profile %v does not peak in the middle", profile)
+	}
+
+	if profile := SizeProfile(OMEGA, 5); len(profile) != 6 {
+		t.Errorf("len(SizeProfile(Ω, 5)) = %d, want 6", len(profile))
+	}
+}
+
+func TestReduceContext(t *testing.T) {
+	expr, _ := Parse("_PLUS _2 _3")
+	result, _, err := ReduceContext(context.Background(), expr, 1000)
+	if err != nil || ToInt(result) != 5 {
+		t.Errorf("ReduceContext(PLUS 2 3) = %s, %v, want 5", result, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, steps, err := ReduceContext(ctx, OMEGA, 1000)
+	if !errors.Is(err, context.Canceled) || steps != 0 || result != OMEGA {
+		t.Errorf("ReduceContext with a canceled context = %s after %d steps, %v", result, steps, err)
+	}
+}
+
+func TestCompareBranchSkip(t *testing.T) {
+	expr, _ := Parse("_IF _TRUE _1 (_MULT _5 _5)")
+	withSkip, withoutSkip := CompareBranchSkip(expr, 10000)
+	if withSkip >= withoutSkip {
+		t.Errorf("CompareBranchSkip(IF TRUE 1 (MULT 5 5)) = %d, %d, expected fewer steps with skipping", withSkip, withoutSkip)
+	}
+
+	// Without a conditional both orders do the same work
+	expr, _ = Parse("_PLUS _2 _3")
+	withSkip, withoutSkip = CompareBranchSkip(expr, 10000)
+	if withSkip != withoutSkip {
+		t.Errorf("CompareBranchSkip(PLUS 2 3) = %d, %d, expected equal counts", withSkip, withoutSkip)
+	}
+}
+
+func TestReduceWithStrategy(t *testing.T) {
+	// K I Ω: normal order discards the divergent argument
+	expr, _ := Parse("(λx.λy.y) ((λx.x x) (λx.x x))")
+	result, steps := ReduceWithStrategy(expr, 100, NormalOrder)
+	if steps != 1 || result.String() != "λy.y" {
+		t.Errorf("normal order = %s after %d steps, want λy.y after 1", result, steps)
+	}
+	if _, steps := ReduceWithStrategy(expr, 100, ApplicativeOrder); steps != 100 {
+		t.Errorf("applicative order stopped after %d steps, want the limit of 100", steps)
+	}
+
+	// Both reach the same normal form when applicative order terminates
+	expr, _ = Parse("_MULT _2 _3")
+	normal, _ := ReduceWithStrategy(expr, 1000, NormalOrder)
+	applicative, _ := ReduceWithStrategy(expr, 1000, ApplicativeOrder)
+	if !AlphaEquivalent(normal, applicative) {
+		t.Errorf("normal form %s, applicative %s", normal, applicative)
+	}
+
+	for _, s := range []Strategy{NormalOrder, ApplicativeOrder} {
+		if parsed, err := ParseStrategy(s.String()); err != nil || parsed != s {
+			t.Errorf("ParseStrategy(%q) = %v, %v", s, parsed, err)
+		}
+	}
+	if _, err := ParseStrategy("lazy"); err == nil {
+		t.Errorf("ParseStrategy(lazy) succeeded")
+	}
+}
+
+func TestReduceDepthLimit(t *testing.T) {
+	expr, _ := Parse("_MULT _30 _30")
+	result, steps, err := ReduceDepthLimit(expr, 100000, 500)
+	if !errors.Is(err, ErrTooDeep) {
+		t.Fatalf("ReduceDepthLimit(MULT 30 30) error = %v after %d steps, want ErrTooDeep", err, steps)
+	}
+	if d := Depth(result); d > 500 {
+		t.Errorf("returned term has depth %d, over the limit", d)
+	}
+
+	expr, _ = Parse("_MULT _3 _4")
+	result, _, err = ReduceDepthLimit(expr, 1000, 500)
+	if err != nil || ToInt(result) != 12 {
+		t.Errorf("ReduceDepthLimit(MULT 3 4) = %s, %v, want 12", result, err)
+	}
+}
+
+func TestReduceTrace(t *testing.T) {
+	expr, _ := Parse("_PLUS _1 _1")
+	var seen []int
+	var prev Term = expr
+	result, steps := ReduceTrace(expr, 0, func(step int, before, after Term) {
+		seen = append(seen, step)
+		if before != prev {
+			t.Errorf("step %d: before is not the previous after", step)
+		}
+		if next, _ := before.BetaReduce(); !AlphaEquivalent(next, after) {
+			t.Errorf("step %d: after is %s, want %s", step, after, next)
+		}
+		prev = after
+	})
+
+	want, wantSteps := Reduce(expr, 0)
+	if !AlphaEquivalent(result, want) || steps != wantSteps {
+		t.Errorf("ReduceTrace = %s in %d steps, Reduce gives %s in %d", result, steps, want, wantSteps)
+	}
+	if len(seen) != steps || seen[0] != 1 || seen[len(seen)-1] != steps {
+		t.Errorf("callback saw steps %v, want 1 to %d", seen, steps)
+	}
+}
+
+func TestReduceDerivation(t *testing.T) {
+	term, err := Parse(`(λx.x) ((λy.y) z)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ReduceDerivation(term, 0)
+	want := []string{"β: (λx.x) ((λy.y) z)", "β: (λy.y) z"}
+	if len(got) != len(want) {
+		t.Fatalf("ReduceDerivation = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// η-steps follow the β-normal form
+	term, err = Parse(`λx.(λy.f y) x`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = ReduceDerivation(term, 0)
+	if len(got) != 2 || !strings.HasPrefix(got[0], "β: ") || got[1] != "η: λx.f x" {
+		t.Errorf("ReduceDerivation(λx.(λy.f y) x) = %q", got)
+	}
+
+	// Long redexes are truncated, never mid-rune: at most the "β: " prefix,
+	// derivationWidth runes and "…"
+	got = ReduceDerivation(Application{Func: FACTORIAL, Arg: ChurchNumeral(2)}, 3)
+	if len(got) != 3 {
+		t.Fatalf("ReduceDerivation stopped after %d steps, want 3", len(got))
+	}
+	for _, line := range got {
+		if n := utf8.RuneCountInString(line); n > derivationWidth+4 || !utf8.ValidString(line) {
+			t.Errorf("line %q is not truncated", line)
+		}
+	}
+}
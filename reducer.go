@@ -0,0 +1,196 @@
+package lambda
+
+// Reducer reduces terms like Reduce, with options trading the purity of
+// the reduction for speed. The zero value reduces exactly like Reduce with
+// the default limit.
+type Reducer struct {
+	// Limit is the maximum number of steps. If 0 or negative,
+	// DefaultStepLimit is used; a positive Limit overrides it.
+	Limit int
+
+	// NumericFastPath computes PRED (DEC) and SUB applied to Church
+	// numerals in Go, in a single step, instead of reducing them through
+	// the O(n) pair encoding, and MINF and MAXF instead of comparing their
+	// operands through SUB. It only applies once the arguments are
+	// numerals; otherwise the redex is reduced as usual, so the result is
+	// the one pure reduction would reach.
+	NumericFastPath bool
+
+	// Accelerate extends the numeric fast path to PLUS (ADD), MULT (MUL),
+	// POW and MOD, the arithmetic combinators whose results interpreters
+	// usually compute natively. It implies NumericFastPath. Results larger
+	// than about a million are left to reduction.
+	Accelerate bool
+}
+
+// ReduceAccelerated reduces obj like Reduce, but computes arithmetic
+// combinators fully applied to Church numerals in Go, in a single step,
+// instead of through thousands of β-reductions. The normal form reached is
+// the same as with Reduce, up to α-equivalence.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReduceAccelerated(obj Term, limit int) (Term, int) {
+	return (&Reducer{Limit: limit, Accelerate: true}).Reduce(obj)
+}
+
+// Reduce reduces obj in normal order, returning the reduced term and the
+// number of steps performed. A fast path counts as one step.
+func (r *Reducer) Reduce(obj Term) (Term, int) {
+	limit := r.Limit
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	steps := 0
+	for steps < limit {
+		reduced, didReduce := r.step(obj)
+		if !didReduce {
+			break
+		}
+		obj = reduced
+		steps++
+	}
+	return obj, steps
+}
+
+// step performs one reduction step, at the same position as BetaReduce.
+func (r *Reducer) step(t Term) (Term, bool) {
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		if body, ok := r.step(t.Body); ok {
+			return Abstraction{Param: t.Param, Body: body}, true
+		}
+	case Application:
+		if r.NumericFastPath || r.Accelerate {
+			if result, ok := r.numericFastPath(t); ok {
+				return result, true
+			}
+		}
+		if result, ok := t.contract(); ok {
+			return result, true
+		}
+		if f, ok := r.step(t.Func); ok {
+			return Application{Func: f, Arg: t.Arg}, true
+		}
+		if a, ok := r.step(t.Arg); ok {
+			return Application{Func: t.Func, Arg: a}, true
+		}
+	case NumeralApply:
+		if f, ok := r.step(t.F); ok {
+			return NumeralApply{N: t.N, Param: t.Param, F: f}, true
+		}
+	}
+	return nil, false
+}
+
+// maxFastNumeral bounds the numerals built by the fast path, as a Church
+// numeral takes memory proportional to its value.
+const maxFastNumeral = 1 << 20
+
+// primitive is an arithmetic combinator computed by the fast path.
+type primitive struct {
+	term  Term // the package constant, compared by identity
+	arity int
+	// accelerated is set for combinators only computed by Accelerate
+	accelerated bool
+	// apply computes the result, reporting false if it is too large
+	apply func(args []int) (int, bool)
+}
+
+// primitives lists the combinators with a numeric fast path
+var primitives = []primitive{
+	{PRED, 1, false, func(args []int) (int, bool) {
+		if args[0] == 0 {
+			return 0, true
+		}
+		return args[0] - 1, true
+	}},
+	{SUB, 2, false, func(args []int) (int, bool) {
+		if args[1] >= args[0] {
+			return 0, true
+		}
+		return args[0] - args[1], true
+	}},
+	{MINF, 2, false, func(args []int) (int, bool) {
+		return min(args[0], args[1]), true
+	}},
+	{MAXF, 2, false, func(args []int) (int, bool) {
+		return max(args[0], args[1]), true
+	}},
+	{PLUS, 2, true, func(args []int) (int, bool) {
+		sum := args[0] + args[1]
+		return sum, sum <= maxFastNumeral
+	}},
+	{MULT, 2, true, func(args []int) (int, bool) {
+		if args[0] == 0 || args[1] == 0 {
+			return 0, true
+		}
+		if args[0] > maxFastNumeral/args[1] {
+			return 0, false
+		}
+		return args[0] * args[1], true
+	}},
+	{POW, 2, true, func(args []int) (int, bool) {
+		// b^0 reduces to λx.x, only η-equivalent to the numeral 1
+		if args[1] == 0 {
+			return 0, false
+		}
+		result := 1
+		for i := 0; i < args[1]; i++ {
+			if args[0] == 0 {
+				return 0, true
+			}
+			if args[0] == 1 {
+				break
+			}
+			if result > maxFastNumeral/args[0] {
+				return 0, false
+			}
+			result *= args[0]
+		}
+		return result, true
+	}},
+	{MOD, 2, true, func(args []int) (int, bool) {
+		// MOD m 0 is 0
+		if args[1] == 0 {
+			return 0, true
+		}
+		return args[0] % args[1], true
+	}},
+}
+
+// numericFastPath computes a primitive fully applied to numerals. Arguments
+// are never reduced first: SUB for instance ignores its first argument when
+// the second is 0, and a divergent first argument must not prevent that.
+func (r *Reducer) numericFastPath(t Application) (Term, bool) {
+	// Collect the spine, arguments in order
+	var args []Term
+	var head Term = t
+	for {
+		app, ok := unwrap(head).(Application)
+		if !ok {
+			break
+		}
+		args = append([]Term{app.Arg}, args...)
+		head = app.Func
+	}
+
+	for _, prim := range primitives {
+		if head != prim.term || len(args) != prim.arity || (prim.accelerated && !r.Accelerate) {
+			continue
+		}
+		values := make([]int, len(args))
+		for i, arg := range args {
+			n, ok := AsInt(arg)
+			if !ok {
+				return nil, false
+			}
+			values[i] = n
+		}
+		n, ok := prim.apply(values)
+		if !ok {
+			return nil, false
+		}
+		return ChurchNumeral(n), true
+	}
+	return nil, false
+}
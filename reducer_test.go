@@ -0,0 +1,117 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestReducerZeroValue(t *testing.T) {
+	expr, _ := Parse("_PLUS _2 _3")
+	got, steps := (&Reducer{}).Reduce(expr)
+	want, wantSteps := Reduce(expr, 0)
+	if !AlphaEquivalent(got, want) || steps != wantSteps {
+		t.Errorf("Reducer{}.Reduce = %s in %d steps, Reduce = %s in %d steps", got, steps, want, wantSteps)
+	}
+}
+
+func TestReducerNumericFastPath(t *testing.T) {
+	tests := []string{
+		"_PRED _5",
+		"_PRED _0",
+		"_DEC _3",
+		"_SUB _7 _3",
+		"_SUB _2 _5",
+		"_SUB (_PRED _9) (_PLUS _2 _1)",
+		"_PRED (_SUB _10 _4)",
+		"λy._SUB _3 y",
+	}
+
+	fast := &Reducer{Limit: 100000, NumericFastPath: true}
+	for _, input := range tests {
+		expr, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		want, pureSteps := Reduce(expr, 100000)
+		got, fastSteps := fast.Reduce(expr)
+		if !AlphaEquivalent(got, want) {
+			t.Errorf("%s: fast path gives %s, pure reduction %s", input, got, want)
+		}
+		if fastSteps > pureSteps {
+			t.Errorf("%s: fast path took %d steps, pure reduction %d", input, fastSteps, pureSteps)
+		}
+	}
+}
+
+func TestReducerNumericFastPathSteps(t *testing.T) {
+	expr, _ := Parse("_SUB _20 _7")
+	_, pureSteps := Reduce(expr, 100000)
+	_, fastSteps := (&Reducer{Limit: 100000, NumericFastPath: true}).Reduce(expr)
+	if fastSteps != 1 {
+		t.Errorf("SUB 20 7 took %d steps with the fast path, want 1 (pure: %d)", fastSteps, pureSteps)
+	}
+}
+
+func TestReducerNumericFastPathLazy(t *testing.T) {
+	// SUB m n is n PRED m: this n discards the divergent Ω it is given
+	// and never uses PRED, so SUB must not be forced to normalize it
+	expr, _ := Parse("_SUB _0 (λa.λb.b ((λx.x x) (λx.x x)))")
+	want, pureSteps := Reduce(expr, 1000)
+	if want.String() != "λx.x" {
+		t.Fatalf("pure reduction gives %s in %d steps, want λx.x", want, pureSteps)
+	}
+	got, steps := (&Reducer{Limit: 1000, NumericFastPath: true}).Reduce(expr)
+	if !AlphaEquivalent(got, want) || steps != pureSteps {
+		t.Errorf("fast path gives %s in %d steps, want %s in %d", got, steps, want, pureSteps)
+	}
+}
+
+func TestReduceAccelerated(t *testing.T) {
+	tests := []string{
+		"_MULT _3 _4",
+		"_POW _2 _3",
+		"_POW _0 _0",
+		"_PLUS (_MULT _2 _3) _4",
+		"_MOD _7 _3",
+		"_MOD _4 _0",
+		"_ADD _1 (_SUB _6 _2)",
+		"_MULT _0 ((λx.x x) (λx.x x))",
+		"λy._MULT y _2",
+	}
+
+	for _, input := range tests {
+		expr, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		got, fastSteps := ReduceAccelerated(expr, 100000)
+		want, pureSteps := Reduce(expr, 100000)
+		if !AlphaEquivalent(got, want) {
+			t.Errorf("%s: accelerated gives %s, pure reduction %s", input, got, want)
+		}
+		if fastSteps > pureSteps {
+			t.Errorf("%s: accelerated took %d steps, pure reduction %d", input, fastSteps, pureSteps)
+		}
+	}
+}
+
+func TestReduceAcceleratedSteps(t *testing.T) {
+	expr, _ := Parse("_POW _2 _3")
+	if _, steps := ReduceAccelerated(expr, 0); steps != 1 {
+		t.Errorf("ReduceAccelerated(POW 2 3) took %d steps, want 1", steps)
+	}
+
+	// NumericFastPath alone leaves MULT to reduction
+	expr, _ = Parse("_MULT _3 _4")
+	_, pureSteps := Reduce(expr, 0)
+	if _, steps := (&Reducer{NumericFastPath: true}).Reduce(expr); steps != pureSteps {
+		t.Errorf("NumericFastPath reduced MULT 3 4 in %d steps, want %d", steps, pureSteps)
+	}
+}
+
+func TestReduceAcceleratedLargeResult(t *testing.T) {
+	// Too large to compute natively, left to reduction
+	expr, _ := Parse("_POW _10 _9")
+	if _, steps := ReduceAccelerated(expr, 5); steps != 5 {
+		t.Errorf("ReduceAccelerated(POW 10 9) stopped after %d steps", steps)
+	}
+}
@@ -0,0 +1,178 @@
+package lambda
+
+import (
+	"fmt"
+)
+
+// Subterm paths
+//
+// A path locates a subterm by the child index taken at each step from the
+// root: 0 for the Func and 1 for the Arg of an Application, 0 for the Body
+// of an Abstraction and 0 for the F of a NumeralApply. LazyScripts are
+// looked through and do not add a step.
+
+// Walk calls fn for t and each of its subterms in pre-order, with the path
+// of the subterm. Walking stops as soon as fn returns false. The path slice
+// is reused between calls; copy it to keep it.
+func Walk(t Term, fn func(sub Term, path []int) bool) {
+	walk(t, nil, fn)
+}
+
+func walk(t Term, path []int, fn func(Term, []int) bool) bool {
+	t = unwrap(t)
+	if !fn(t, path) {
+		return false
+	}
+	switch t := t.(type) {
+	case Abstraction:
+		return walk(t.Body, append(path, 0), fn)
+	case Application:
+		return walk(t.Func, append(path, 0), fn) && walk(t.Arg, append(path, 1), fn)
+	case NumeralApply:
+		return walk(t.F, append(path, 0), fn)
+	}
+	return true
+}
+
+// Contains reports whether sub appears anywhere in t, up to α-equivalence.
+// Subterms are compared as written, so a variable bound in t matches a
+// free variable of the same name in sub.
+func Contains(t Term, sub Term) bool {
+	_, _, found := FindSubterm(t, func(s Term) bool {
+		return AlphaEquivalent(s, sub)
+	})
+	return found
+}
+
+// FindSubterm returns the first subterm of t, in pre-order, for which pred
+// returns true, along with its path.
+func FindSubterm(t Term, pred func(Term) bool) (Term, []int, bool) {
+	var found Term
+	var foundPath []int
+	Walk(t, func(sub Term, path []int) bool {
+		if pred(sub) {
+			found = sub
+			foundPath = append([]int{}, path...)
+			return false
+		}
+		return true
+	})
+	return found, foundPath, found != nil
+}
+
+// ReplaceAtPath returns a copy of t with the subterm at path replaced by
+// replacement. Free variables of the replacement may become bound by the
+// binders around the path. An error is returned if the path does not
+// exist in t.
+func ReplaceAtPath(t Term, path []int, replacement Term) (Term, error) {
+	if len(path) == 0 {
+		return replacement, nil
+	}
+
+	i, rest := path[0], path[1:]
+	switch t := unwrap(t).(type) {
+	case Abstraction:
+		if i == 0 {
+			body, err := ReplaceAtPath(t.Body, rest, replacement)
+			if err != nil {
+				return nil, err
+			}
+			return Abstraction{Param: t.Param, Body: body}, nil
+		}
+	case Application:
+		switch i {
+		case 0:
+			f, err := ReplaceAtPath(t.Func, rest, replacement)
+			if err != nil {
+				return nil, err
+			}
+			return Application{Func: f, Arg: t.Arg}, nil
+		case 1:
+			a, err := ReplaceAtPath(t.Arg, rest, replacement)
+			if err != nil {
+				return nil, err
+			}
+			return Application{Func: t.Func, Arg: a}, nil
+		}
+	case NumeralApply:
+		if i == 0 {
+			f, err := ReplaceAtPath(t.F, rest, replacement)
+			if err != nil {
+				return nil, err
+			}
+			// F must not contain Param free
+			param := t.Param
+			if fv := f.FreeVars(); fv[param] {
+				param = freshVar(param, fv)
+			}
+			return NumeralApply{N: t.N, Param: param, F: f}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid path: no child %d in %s", i, t)
+}
+
+// Redexes returns the paths of the β-redexes of t, in pre-order, so the
+// first one is the redex normal order reduction contracts next. Compact
+// numeral applications count as redexes, as BetaReduce contracts them.
+func Redexes(t Term) [][]int {
+	var paths [][]int
+	Walk(t, func(sub Term, path []int) bool {
+		if app, ok := sub.(Application); ok {
+			if _, ok := app.contract(); ok {
+				paths = append(paths, append([]int{}, path...))
+			}
+		}
+		return true
+	})
+	return paths
+}
+
+// ContractRedex contracts the redex at path in t, whichever its position,
+// so reduction can be driven in any order. An error is returned if the
+// path does not exist in t or does not point at a redex.
+func ContractRedex(t Term, path []int) (Term, error) {
+	sub, err := subtermAt(t, path)
+	if err != nil {
+		return nil, err
+	}
+	app, ok := sub.(Application)
+	if !ok {
+		return nil, fmt.Errorf("not a redex: %s", sub)
+	}
+	contracted, ok := app.contract()
+	if !ok {
+		return nil, fmt.Errorf("not a redex: %s", sub)
+	}
+	// The contracted term has no free variable the redex did not have, so
+	// nothing is captured
+	return ReplaceAtPath(t, path, contracted)
+}
+
+// subtermAt returns the subterm of t at path.
+func subtermAt(t Term, path []int) (Term, error) {
+	for _, i := range path {
+		switch term := unwrap(t).(type) {
+		case Abstraction:
+			if i == 0 {
+				t = term.Body
+				continue
+			}
+		case Application:
+			switch i {
+			case 0:
+				t = term.Func
+				continue
+			case 1:
+				t = term.Arg
+				continue
+			}
+		case NumeralApply:
+			if i == 0 {
+				t = term.F
+				continue
+			}
+		}
+		return nil, fmt.Errorf("invalid path: no child %d in %s", i, t)
+	}
+	return unwrap(t), nil
+}
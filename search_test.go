@@ -0,0 +1,209 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	term, err := Parse("λx.f (x y)")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	var visited []string
+	var paths [][]int
+	Walk(term, func(sub Term, path []int) bool {
+		visited = append(visited, sub.String())
+		paths = append(paths, append([]int{}, path...))
+		return true
+	})
+
+	wantVisited := []string{"λx.f (x y)", "f (x y)", "f", "x y", "x", "y"}
+	wantPaths := "[[] [0] [0 0] [0 1] [0 1 0] [0 1 1]]"
+	if !reflect.DeepEqual(visited, wantVisited) {
+		t.Errorf("visited %v, want %v", visited, wantVisited)
+	}
+	if got := fmt.Sprint(paths); got != wantPaths {
+		t.Errorf("paths = %s, want %s", got, wantPaths)
+	}
+
+	// Stops when fn returns false
+	count := 0
+	Walk(term, func(Term, []int) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("Walk visited %d terms after stopping, want 3", count)
+	}
+}
+
+func TestContains(t *testing.T) {
+	term, err := Parse("λn.n (λx.x x) (λy.y y) z")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	tests := []struct {
+		sub  string
+		want bool
+	}{
+		{"λa.a a", true},
+		{"(λx.x x) (λy.y y)", false},
+		{"z", true},
+		{"w", false},
+		{"λa.a", false},
+	}
+
+	for _, tt := range tests {
+		sub, err := Parse(tt.sub)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.sub, err)
+		}
+		if got := Contains(term, sub); got != tt.want {
+			t.Errorf("Contains(%s, %s) = %v, want %v", term, tt.sub, got, tt.want)
+		}
+	}
+
+	// Detecting an embedded Ω
+	if !Contains(Application{Func: K, Arg: OMEGA}, OMEGA) {
+		t.Error("K Ω should contain Ω")
+	}
+}
+
+func TestFindSubterm(t *testing.T) {
+	term, err := Parse("f (g (λx.x)) (λy.y)")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	sub, path, ok := FindSubterm(term, func(s Term) bool {
+		_, isAbs := s.(Abstraction)
+		return isAbs
+	})
+	if !ok {
+		t.Fatal("FindSubterm found nothing")
+	}
+	if sub.String() != "λx.x" {
+		t.Errorf("found %s, want λx.x", sub)
+	}
+	if want := []int{0, 1, 1}; !reflect.DeepEqual(path, want) {
+		t.Errorf("path = %v, want %v", path, want)
+	}
+
+	if _, _, ok := FindSubterm(term, func(Term) bool { return false }); ok {
+		t.Error("FindSubterm should report false when nothing matches")
+	}
+}
+
+func TestReplaceAtPath(t *testing.T) {
+	term, err := Parse("f (g (h x y)) z")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	tests := []struct {
+		path []int
+		want string
+	}{
+		{nil, "w"},
+		{[]int{1}, "f (g (h x y)) w"},
+		{[]int{0, 1, 1, 1}, "f (g (h x w)) z"},
+		{[]int{0, 1, 1, 0, 0}, "f (g (w x y)) z"},
+	}
+
+	for _, tt := range tests {
+		got, err := ReplaceAtPath(term, tt.path, Var{Name: "w"})
+		if err != nil {
+			t.Errorf("ReplaceAtPath(%v) error: %v", tt.path, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ReplaceAtPath(%v) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+
+	// The original term is unchanged
+	if term.String() != "f (g (h x y)) z" {
+		t.Errorf("original term modified: %s", term)
+	}
+}
+
+func TestReplaceAtPathFound(t *testing.T) {
+	// Replace a recognized subterm with a simpler equivalent
+	term := Application{Func: Var{Name: "f"}, Arg: Application{Func: I, Arg: Var{Name: "x"}}}
+	_, path, ok := FindSubterm(term, func(s Term) bool { return AlphaEquivalent(s, I) })
+	if !ok {
+		t.Fatal("I not found")
+	}
+	got, err := ReplaceAtPath(term, path[:len(path)-1], Var{Name: "x"})
+	if err != nil {
+		t.Fatalf("ReplaceAtPath error: %v", err)
+	}
+	if got.String() != "f x" {
+		t.Errorf("got %s, want f x", got)
+	}
+}
+
+func TestReplaceAtPathInvalid(t *testing.T) {
+	term, err := Parse("λx.f x")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	for _, path := range [][]int{{1}, {0, 2}, {0, 0, 0}, {0, 1, 0}} {
+		if _, err := ReplaceAtPath(term, path, Var{Name: "w"}); err == nil {
+			t.Errorf("ReplaceAtPath(%v) should fail", path)
+		}
+	}
+}
+
+func TestRedexes(t *testing.T) {
+	term, err := Parse(`λa.(λx.x x) ((λy.y) (a ((λz.z) b)))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Redexes(term)
+	want := [][]int{{0}, {0, 1}, {0, 1, 1, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Redexes = %v, want %v", got, want)
+	}
+	if got := Redexes(Var{Name: "x"}); len(got) != 0 {
+		t.Errorf("Redexes(x) = %v", got)
+	}
+}
+
+func TestContractRedex(t *testing.T) {
+	term, err := Parse(`(λx.x x) ((λy.y) z)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Contract the argument first, against normal order
+	inner, err := ContractRedex(term, []int{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.String() != "(λx.x x) z" {
+		t.Errorf("ContractRedex([1]) = %s, want (λx.x x) z", inner)
+	}
+	for len(Redexes(inner)) > 0 {
+		inner, err = ContractRedex(inner, Redexes(inner)[len(Redexes(inner))-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	leftmost, _ := Reduce(term, 0)
+	if !AlphaEquivalent(inner, leftmost) {
+		t.Errorf("innermost order reached %s, normal order %s", inner, leftmost)
+	}
+
+	for _, path := range [][]int{{0}, {1, 0}, {2}, {0, 0, 0, 0}} {
+		if _, err := ContractRedex(term, path); err == nil {
+			t.Errorf("ContractRedex(%v) succeeded", path)
+		}
+	}
+}
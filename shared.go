@@ -0,0 +1,262 @@
+package lambda
+
+import (
+	"fmt"
+)
+
+// Graph reduction with sharing
+//
+// ReduceShared normalizes terms by evaluating them against environments
+// instead of substituting. Arguments are bound as thunks: a thunk is
+// evaluated at most once and every occurrence of the variable shares the
+// result, so (λx.PLUS x x) M reduces M only once where Reduce copies it
+// and reduces both copies. The normal form is then read back into a Term.
+
+// sharedValue is a term evaluated to weak head normal form.
+type sharedValue interface{}
+
+// sharedClosure is an abstraction together with the bindings of its free
+// variables.
+type sharedClosure struct {
+	param string
+	body  Term
+	env   *sharedEnv
+}
+
+// sharedNeutral is a variable without binding applied to arguments.
+type sharedNeutral struct {
+	head  string
+	spine []*sharedThunk
+}
+
+// sharedNumeral is a compact Numeral, optionally applied to its function.
+type sharedNumeral struct {
+	n uint64
+	f *sharedThunk // nil until applied
+}
+
+// sharedStuck is an application left unreduced because the step limit was
+// reached.
+type sharedStuck struct {
+	fn  sharedValue
+	arg *sharedThunk
+}
+
+// sharedThunk is a delayed computation, either a term in an environment or
+// the application of one thunk to another. Once forced it holds its value,
+// and once read back the resulting term.
+type sharedThunk struct {
+	term   Term
+	env    *sharedEnv
+	fn     *sharedThunk
+	arg    *sharedThunk
+	val    sharedValue
+	quoted Term
+	forced bool // val was computed by force
+}
+
+// sharedEnv binds variable names to thunks.
+type sharedEnv struct {
+	name  string
+	thunk *sharedThunk
+	next  *sharedEnv
+}
+
+func (e *sharedEnv) lookup(name string) (*sharedThunk, bool) {
+	for ; e != nil; e = e.next {
+		if e.name == name {
+			return e.thunk, true
+		}
+	}
+	return nil, false
+}
+
+// sharedReducer holds the state of one ReduceShared run.
+type sharedReducer struct {
+	steps int
+	limit int
+	scope map[string]int // names bound or free during read back
+	stats SharingStats
+}
+
+// SharingStats describes the work done by a shared reduction.
+type SharingStats struct {
+	Steps  int // Reduction steps, counted as by ReduceShared
+	Thunks int // Delayed arguments created
+	Forces int // Thunks evaluated
+	Hits   int // Uses of a thunk that reused its earlier evaluation
+}
+
+// ReduceShared reduces obj to normal form like Reduce, but shares
+// arguments instead of copying them (call-by-need), so an argument used
+// several times is reduced only once. Steps are counted like Reduce
+// counts them; sharing usually makes the count much lower.
+//
+// If the limit is reached, the partially reduced term is returned, in which
+// the remaining redexes are left as they are. If limit is 0 or negative,
+// DefaultStepLimit is used.
+func ReduceShared(obj Term, limit int) (Term, int) {
+	result, stats := ReduceSharedStats(obj, limit)
+	return result, stats.Steps
+}
+
+// ReduceSharedStats is like ReduceShared, but also reports how much the
+// reduction benefited from sharing: every cache hit is an argument
+// evaluation that Reduce would have repeated on a copy.
+func ReduceSharedStats(obj Term, limit int) (Term, SharingStats) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+
+	r := &sharedReducer{limit: limit, scope: make(map[string]int)}
+	for name := range obj.FreeVars() {
+		r.scope[name]++
+	}
+	result := r.quote(r.eval(obj, nil))
+	r.stats.Steps = r.steps
+	return result, r.stats
+}
+
+// thunk creates a delayed computation, either of t in env or, if fn is not
+// nil, of fn applied to arg.
+func (r *sharedReducer) thunk(t Term, env *sharedEnv, fn, arg *sharedThunk) *sharedThunk {
+	r.stats.Thunks++
+	return &sharedThunk{term: t, env: env, fn: fn, arg: arg}
+}
+
+// step records a reduction step, reporting false if the limit is reached.
+func (r *sharedReducer) step() bool {
+	if r.steps >= r.limit {
+		return false
+	}
+	r.steps++
+	return true
+}
+
+// eval evaluates t in env to weak head normal form.
+func (r *sharedReducer) eval(t Term, env *sharedEnv) sharedValue {
+	switch t := t.(type) {
+	case *LazyScript:
+		return r.eval(t.parse(), env)
+	case Var:
+		if th, ok := env.lookup(t.Name); ok {
+			return r.force(th)
+		}
+		return sharedNeutral{head: t.Name}
+	case Abstraction:
+		return sharedClosure{param: t.Param, body: t.Body, env: env}
+	case Application:
+		fn := r.eval(t.Func, env)
+		return r.apply(fn, r.thunk(t.Arg, env, nil, nil))
+	case Numeral:
+		return sharedNumeral{n: uint64(t)}
+	case NumeralApply:
+		return r.eval(t.Expand(), env)
+	}
+	panic("ReduceShared: unknown term type")
+}
+
+// force evaluates a thunk once and returns its value.
+func (r *sharedReducer) force(th *sharedThunk) sharedValue {
+	if th.forced {
+		r.stats.Hits++
+	}
+	if th.val == nil {
+		r.stats.Forces++
+		if th.fn != nil {
+			th.val = r.apply(r.force(th.fn), th.arg)
+		} else {
+			th.val = r.eval(th.term, th.env)
+		}
+		th.forced = true
+		// Release what is no longer needed
+		th.term, th.env, th.fn, th.arg = nil, nil, nil, nil
+	}
+	return th.val
+}
+
+// apply applies a function value to an argument.
+func (r *sharedReducer) apply(fn sharedValue, arg *sharedThunk) sharedValue {
+	switch f := fn.(type) {
+	case sharedNeutral:
+		spine := make([]*sharedThunk, len(f.spine), len(f.spine)+1)
+		copy(spine, f.spine)
+		return sharedNeutral{head: f.head, spine: append(spine, arg)}
+	case sharedStuck:
+		return sharedStuck{fn: fn, arg: arg}
+	}
+
+	if !r.step() {
+		return sharedStuck{fn: fn, arg: arg}
+	}
+
+	switch f := fn.(type) {
+	case sharedClosure:
+		// (λx.t) s → t with x bound to s
+		return r.eval(f.body, &sharedEnv{name: f.param, thunk: arg, next: f.env})
+	case sharedNumeral:
+		if f.f == nil {
+			// Numeral(n) f → NumeralApply{n, f}
+			return sharedNumeral{n: f.n, f: arg}
+		}
+		// NumeralApply{n, f} x → f^n(x), each application a shared thunk
+		result := arg
+		for i := uint64(0); i < f.n; i++ {
+			result = r.thunk(nil, nil, f.f, result)
+		}
+		return r.force(result)
+	}
+	panic("ReduceShared: unknown value type")
+}
+
+// bind reserves a fresh name based on name for the duration of a read back.
+func (r *sharedReducer) bind(name string) string {
+	fresh := name
+	for i := 0; r.scope[fresh] > 0; i++ {
+		fresh = fmt.Sprintf("%s%d", name, i)
+	}
+	r.scope[fresh]++
+	return fresh
+}
+
+func (r *sharedReducer) unbind(name string) {
+	r.scope[name]--
+}
+
+// quoteThunk reads back the value of a thunk. The term is computed once and
+// shared by every occurrence of the thunk: binders in it were named apart
+// from every name in scope, so it can be reused without capture.
+func (r *sharedReducer) quoteThunk(th *sharedThunk) Term {
+	if th.quoted == nil {
+		th.quoted = r.quote(r.force(th))
+	}
+	return th.quoted
+}
+
+// quote reads a value back into a term, normalizing under binders.
+func (r *sharedReducer) quote(v sharedValue) Term {
+	switch v := v.(type) {
+	case sharedClosure:
+		name := r.bind(v.param)
+		defer r.unbind(name)
+		bound := &sharedThunk{val: sharedNeutral{head: name}}
+		body := r.eval(v.body, &sharedEnv{name: v.param, thunk: bound, next: v.env})
+		return Abstraction{Param: name, Body: r.quote(body)}
+	case sharedNeutral:
+		var t Term = Var{Name: v.head}
+		for _, arg := range v.spine {
+			t = Application{Func: t, Arg: r.quoteThunk(arg)}
+		}
+		return t
+	case sharedNumeral:
+		if v.f == nil {
+			return Numeral(v.n)
+		}
+		name := r.bind("x")
+		defer r.unbind(name)
+		return NumeralApply{N: v.n, Param: name, F: r.quoteThunk(v.f)}
+	case sharedStuck:
+		return Application{Func: r.quote(v.fn), Arg: r.quoteThunk(v.arg)}
+	}
+	panic("ReduceShared: unknown value type")
+}
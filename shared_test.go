@@ -0,0 +1,128 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestReduceShared(t *testing.T) {
+	tests := []string{
+		"_PLUS _2 _3",
+		"_MULT _3 _4",
+		"_FACTORIAL _3",
+		"_SUB _5 _2",
+		"(λx.λy.x y) y",
+		"λx.(λy.λx.y x) x",
+		"(λx._PLUS x x) (_MULT _3 _3)",
+		"_3 f",
+		"_3 f x",
+		"_MAP _SUCC (_CONS _1 (_CONS _2 _NIL))",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			expr, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			want, steps := Reduce(expr, 10000)
+			got, sharedSteps := ReduceShared(expr, 10000)
+			t.Logf("%s: Reduce %d steps, ReduceShared %d steps", input, steps, sharedSteps)
+			if !AlphaEquivalent(got, want) {
+				t.Errorf("ReduceShared(%s) = %s, want %s", input, got, want)
+			}
+		})
+	}
+}
+
+func TestReduceSharedSharing(t *testing.T) {
+	// The argument is reduced once and shared by both occurrences of x
+	expr, err := Parse("(λx._PLUS x x) (_FACTORIAL _3)")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	result, sharedSteps := ReduceShared(expr, 10000)
+	if got := ToInt(result); got != 12 {
+		t.Errorf("ReduceShared = %d, want 12", got)
+	}
+	_, steps := Reduce(expr, 10000)
+	t.Logf("Reduce %d steps, ReduceShared %d steps", steps, sharedSteps)
+	if sharedSteps >= steps {
+		t.Errorf("ReduceShared took %d steps, Reduce %d", sharedSteps, steps)
+	}
+}
+
+func TestReduceSharedStats(t *testing.T) {
+	// x is used twice: the second use reuses the first evaluation
+	expr, _ := Parse("(λx._PLUS x x) (_MULT _2 _3)")
+	result, stats := ReduceSharedStats(expr, 10000)
+	if got := ToInt(result); got != 12 {
+		t.Errorf("ReduceSharedStats = %d, want 12", got)
+	}
+	t.Logf("%+v", stats)
+	if stats.Hits == 0 {
+		t.Errorf("stats = %+v, want cache hits", stats)
+	}
+	if stats.Forces > stats.Thunks {
+		t.Errorf("stats = %+v, forced more thunks than created", stats)
+	}
+	if _, steps := ReduceShared(expr, 10000); steps != stats.Steps {
+		t.Errorf("stats.Steps = %d, ReduceShared took %d", stats.Steps, steps)
+	}
+
+	// Nothing is shared by the identity applied to a variable
+	expr, _ = Parse("(λx.x) y")
+	if _, stats := ReduceSharedStats(expr, 10); stats.Hits != 0 {
+		t.Errorf("stats = %+v, want no cache hits", stats)
+	}
+}
+
+func TestReduceSharedLimit(t *testing.T) {
+	// Ω never normalizes; the partial result is Ω itself
+	result, steps := ReduceShared(OMEGA, 10)
+	if steps != 10 {
+		t.Errorf("steps = %d, want 10", steps)
+	}
+	if !AlphaEquivalent(result, OMEGA) {
+		t.Errorf("ReduceShared(Ω) = %s, want Ω", result)
+	}
+
+	// A partial result is still equivalent to the original term
+	expr, _ := Parse("_PLUS _2 _3")
+	partial, steps := ReduceShared(expr, 3)
+	if steps != 3 {
+		t.Errorf("steps = %d, want 3", steps)
+	}
+	if got := ToInt(partial); got != 5 {
+		t.Errorf("partial result %s reduces to %d, want 5", partial, got)
+	}
+}
+
+func benchmarkReduce(b *testing.B, input string, reduce func(Term, int) (Term, int)) {
+	expr, err := Parse(input)
+	if err != nil {
+		b.Fatalf("Parse error: %v", err)
+	}
+	b.ReportAllocs()
+	steps := 0
+	for i := 0; i < b.N; i++ {
+		_, steps = reduce(expr, 100000)
+	}
+	b.ReportMetric(float64(steps), "steps/op")
+}
+
+func BenchmarkReduce_MULT(b *testing.B) {
+	benchmarkReduce(b, "_MULT _5 _5", Reduce)
+}
+
+func BenchmarkReduceShared_MULT(b *testing.B) {
+	benchmarkReduce(b, "_MULT _5 _5", ReduceShared)
+}
+
+func BenchmarkReduce_FACTORIAL(b *testing.B) {
+	benchmarkReduce(b, "_FACTORIAL _3", Reduce)
+}
+
+func BenchmarkReduceShared_FACTORIAL(b *testing.B) {
+	benchmarkReduce(b, "_FACTORIAL _3", ReduceShared)
+}
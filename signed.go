@@ -0,0 +1,63 @@
+package lambda
+
+// Signed integers
+//
+// A signed integer is encoded as a pair of Church numerals (a, b) standing
+// for a - b. Many pairs denote the same integer; SignedInt builds the
+// normalized one where at least one side is zero.
+var (
+	// SINT := λn.PAIR n ZERO (lift a natural number to a signed integer)
+	SINT = MakeLazyScript(`λn._PAIR n _ZERO`)
+
+	// NEG := λn.PAIR ZERO n (negate a natural number into a signed integer)
+	NEG = MakeLazyScript(`λn._PAIR _ZERO n`)
+
+	// SINT_ADD := λa.λb.PAIR (PLUS (FIRST a) (FIRST b)) (PLUS (SECOND a) (SECOND b))
+	SINT_ADD = MakeLazyScript(`λa.λb._PAIR (_PLUS (_FIRST a) (_FIRST b)) (_PLUS (_SECOND a) (_SECOND b))`)
+
+	// SINT_SUB := λa.λb.PAIR (PLUS (FIRST a) (SECOND b)) (PLUS (SECOND a) (FIRST b))
+	SINT_SUB = MakeLazyScript(`λa.λb._PAIR (_PLUS (_FIRST a) (_SECOND b)) (_PLUS (_SECOND a) (_FIRST b))`)
+
+	// SINT_NEG := λa.PAIR (SECOND a) (FIRST a)
+	SINT_NEG = MakeLazyScript(`λa._PAIR (_SECOND a) (_FIRST a)`)
+)
+
+// SignedInt creates the signed integer encoding of n as the normalized
+// pair λp.p a b with a - b = n and min(a, b) = 0.
+func SignedInt(n int) Term {
+	a, b := n, 0
+	if n < 0 {
+		a, b = 0, -n
+	}
+	return Abstraction{
+		Param: "p",
+		Body: Application{
+			Func: Application{Func: Var{Name: "p"}, Arg: ChurchNumeral(a)},
+			Arg:  ChurchNumeral(b),
+		},
+	}
+}
+
+// FromSignedInt converts a signed integer pair (a, b) back to the Go
+// integer a - b. The term is reduced to normal form first (with at most
+// DefaultStepLimit steps); false is returned if the result is not a pair of
+// numerals.
+func FromSignedInt(t Term) (int, bool) {
+	nf, ok := normalForm(t, DefaultStepLimit)
+	if !ok {
+		return 0, false
+	}
+	first, second, ok := matchPair(nf)
+	if !ok {
+		return 0, false
+	}
+	a, ok := matchNumeral(first)
+	if !ok {
+		return 0, false
+	}
+	b, ok := matchNumeral(second)
+	if !ok {
+		return 0, false
+	}
+	return a - b, true
+}
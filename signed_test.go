@@ -0,0 +1,56 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestSignedIntRoundTrip(t *testing.T) {
+	for _, n := range []int{-5, -1, 0, 1, 7} {
+		got, ok := FromSignedInt(SignedInt(n))
+		if !ok || got != n {
+			t.Errorf("FromSignedInt(SignedInt(%d)) = %d, %v", n, got, ok)
+		}
+	}
+}
+
+func TestSignedIntArithmetic(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"_SINT_SUB (_SINT _2) (_SINT _5)", -3},
+		{"_SINT_SUB (_SINT _5) (_SINT _2)", 3},
+		{"_SINT_SUB (_SINT _4) (_SINT _4)", 0},
+		{"_SINT_ADD (_NEG _2) (_SINT _5)", 3},
+		{"_SINT_ADD (_NEG _2) (_NEG _3)", -5},
+		{"_SINT_NEG (_SINT _4)", -4},
+		{"_SINT_NEG (_NEG _4)", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.expr, err)
+			}
+
+			reduced, _ := Reduce(expr, 5000)
+			got, ok := FromSignedInt(reduced)
+			if !ok {
+				t.Fatalf("%s did not reduce to a signed integer: %s", tt.expr, reduced)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %d, want %d", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromSignedIntInvalid(t *testing.T) {
+	if _, ok := FromSignedInt(I); ok {
+		t.Error("FromSignedInt(I) should fail")
+	}
+	if _, ok := FromSignedInt(Application{Func: Application{Func: PAIR, Arg: TRUE}, Arg: ZERO}); ok {
+		t.Error("FromSignedInt(PAIR TRUE 0) should fail")
+	}
+}
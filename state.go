@@ -0,0 +1,31 @@
+package lambda
+
+// State machines
+//
+// DIV2 and ISODD run STEP2 n times over the initial pair INIT2 and keep one
+// component of the final pair. ITERSTATE generalizes the pattern to any
+// step function over a pair state.
+var (
+	// ITERSTATE := λn.λstep.λinit.FIRST (n step init)
+	ITERSTATE = MakeLazyScript(`λn.λstep.λinit._FIRST (n step init)`)
+)
+
+// IterateState runs step n times over the initial pair init and returns
+// both components of the final state. The result is reduced to normal form
+// first (with at most limit steps, or DefaultStepLimit if limit is 0 or
+// negative);
+// false is returned if it is not a pair.
+func IterateState(step, init Term, n int, limit int) (Term, Term, bool) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	run := Application{
+		Func: Application{Func: ChurchNumeral(n), Arg: step},
+		Arg:  init,
+	}
+	nf, ok := normalForm(run, limit)
+	if !ok {
+		return nil, nil, false
+	}
+	return matchPair(nf)
+}
@@ -0,0 +1,44 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestITERSTATEMatchesDIV2(t *testing.T) {
+	for n := 0; n <= 7; n++ {
+		div2, _ := Reduce(Application{Func: DIV2, Arg: ChurchNumeral(n)}, 5000)
+
+		iter := Application{
+			Func: Application{
+				Func: Application{Func: ITERSTATE, Arg: ChurchNumeral(n)},
+				Arg:  STEP2,
+			},
+			Arg: INIT2,
+		}
+		result, _ := Reduce(iter, 5000)
+
+		if got, want := ToInt(result), ToInt(div2); got != want || got != n/2 {
+			t.Errorf("ITERSTATE %d STEP2 INIT2 = %d, DIV2 %d = %d, want %d", n, got, n, want, n/2)
+		}
+	}
+}
+
+func TestIterateState(t *testing.T) {
+	for n := 0; n <= 5; n++ {
+		half, odd, ok := IterateState(STEP2, INIT2, n, 5000)
+		if !ok {
+			t.Fatalf("IterateState(STEP2, INIT2, %d) did not produce a pair", n)
+		}
+		if got := ToInt(half); got != n/2 {
+			t.Errorf("n=%d: first = %d, want %d", n, got, n/2)
+		}
+		if got := ToBool(odd); got != (n%2 == 1) {
+			t.Errorf("n=%d: second = %v, want %v", n, got, n%2 == 1)
+		}
+	}
+
+	// The state must be a pair
+	if _, _, ok := IterateState(I, ChurchNumeral(1), 3, 100); ok {
+		t.Error("IterateState over a non-pair state should fail")
+	}
+}
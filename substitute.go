@@ -0,0 +1,164 @@
+package lambda
+
+// SubstituteAll replaces every free occurrence of each variable in subst
+// with its replacement, all at once. Unlike a sequence of Substitute calls,
+// replacements are never substituted into each other, so {x→y, y→x}
+// swaps x and y. Bound variables are renamed as needed to avoid capture.
+func SubstituteAll(t Term, subst map[string]Term) Term {
+	return substituteAll(t, subst, nil)
+}
+
+// SubstituteAvoiding is like Substitute, but any bound variable renamed to
+// avoid capture also avoids the names in avoid. Substitute only avoids
+// names free in the replacement and the body, so a fresh name such as y0
+// may later be captured if a transformation inserts a free y0 under the
+// renamed binder; reserving y0 here prevents that.
+func SubstituteAvoiding(t Term, varName string, replacement Term, avoid map[string]bool) Term {
+	return substituteAll(t, map[string]Term{varName: replacement}, avoid)
+}
+
+func substituteAll(t Term, subst map[string]Term, avoid map[string]bool) Term {
+	if len(subst) == 0 {
+		return t
+	}
+
+	switch term := t.(type) {
+	case *LazyScript:
+		return substituteAll(term.parse(), subst, avoid)
+	case Var:
+		if r, ok := subst[term.Name]; ok {
+			return r
+		}
+		return term
+	case Application:
+		return Application{
+			Func: substituteAll(term.Func, subst, avoid),
+			Arg:  substituteAll(term.Arg, subst, avoid),
+		}
+	case Abstraction:
+		inner, param := bindSubst(subst, term.Param, term.Body, avoid)
+		if inner == nil {
+			return term
+		}
+		return Abstraction{Param: param, Body: substituteAll(term.Body, inner, avoid)}
+	case NumeralApply:
+		inner, param := bindSubst(subst, term.Param, term.F, avoid)
+		if inner == nil {
+			return term
+		}
+		// F does not contain Param free, so a renamed Param needs no
+		// substitution in F
+		delete(inner, term.Param)
+		return NumeralApply{N: term.N, Param: param, F: substituteAll(term.F, inner, avoid)}
+	}
+	return t
+}
+
+// bindSubst prepares subst for use under a binder for param with the given
+// body. It returns the substitution to apply to the body, or nil if nothing
+// needs substituting, and the (possibly renamed) binder name. If a
+// replacement has param free, param is renamed and the renaming is added to
+// the returned substitution, choosing a name that is not in reserved.
+func bindSubst(subst map[string]Term, param string, body Term, reserved map[string]bool) (map[string]Term, string) {
+	bodyFree := body.FreeVars()
+	inner := make(map[string]Term)
+	for name, r := range subst {
+		if name != param && bodyFree[name] {
+			inner[name] = r
+		}
+	}
+	if len(inner) == 0 {
+		return nil, param
+	}
+
+	avoid := make(map[string]bool)
+	capture := false
+	for name, r := range inner {
+		avoid[name] = true
+		for k := range r.FreeVars() {
+			avoid[k] = true
+			if k == param {
+				capture = true
+			}
+		}
+	}
+	if !capture {
+		return inner, param
+	}
+
+	for k := range bodyFree {
+		avoid[k] = true
+	}
+	for k := range reserved {
+		avoid[k] = true
+	}
+	newParam := freshVar(param, avoid)
+	inner[param] = Var{Name: newParam}
+	return inner, newParam
+}
+
+// Freshen renames the bound variables of t so that no two binders share a
+// name and no binder has the name of a free variable (the Barendregt
+// convention). Binders keep their name when it is not yet taken; others get
+// a numbered variant of it, such as λx.λx.x becoming λx.λx0.x0. The result
+// is α-equivalent to t, and substituting a free variable of t into any part
+// of it can never capture.
+func Freshen(t Term) Term {
+	taken := t.FreeVars()
+	names := make(map[string]bool)
+	varNames(t, names)
+	return freshen(t, nil, taken, names)
+}
+
+// freshen renames the binders of t, where env maps names bound above t to
+// their new names, taken holds the names already given to binders or free,
+// and names every name occurring in the original term.
+func freshen(t Term, env map[string]string, taken, names map[string]bool) Term {
+	switch term := unwrap(t).(type) {
+	case Var:
+		if name, ok := env[term.Name]; ok {
+			return Var{Name: name}
+		}
+		return term
+	case Application:
+		return Application{
+			Func: freshen(term.Func, env, taken, names),
+			Arg:  freshen(term.Arg, env, taken, names),
+		}
+	case Abstraction:
+		param, inner := freshenBinder(term.Param, env, taken, names)
+		return Abstraction{Param: param, Body: freshen(term.Body, inner, taken, names)}
+	case NumeralApply:
+		// Param does not occur in F
+		param, _ := freshenBinder(term.Param, env, taken, names)
+		return NumeralApply{N: term.N, Param: param, F: freshen(term.F, env, taken, names)}
+	case Numeral:
+		return term
+	}
+	return t
+}
+
+// freshenBinder picks the new name of a binder for param and returns it
+// with the environment for the binder's body. A fresh name also avoids the
+// names of the original term, so that binders further on can keep theirs.
+func freshenBinder(param string, env map[string]string, taken, names map[string]bool) (string, map[string]string) {
+	name := param
+	if taken[name] {
+		avoid := make(map[string]bool, len(taken)+len(names))
+		for k := range taken {
+			avoid[k] = true
+		}
+		for k := range names {
+			avoid[k] = true
+		}
+		name = freshVar(param, avoid)
+	}
+	taken[name] = true
+
+	inner := make(map[string]string, len(env)+1)
+	for k, v := range env {
+		inner[k] = v
+	}
+	inner[param] = name
+	return name, inner
+}
@@ -0,0 +1,124 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestSubstituteAll(t *testing.T) {
+	x, y, z := Var{Name: "x"}, Var{Name: "y"}, Var{Name: "z"}
+
+	tests := []struct {
+		name  string
+		term  Term
+		subst map[string]Term
+		want  string
+	}{
+		{"swap", Application{Func: x, Arg: y}, map[string]Term{"x": y, "y": x}, "y x"},
+		{"empty", Application{Func: x, Arg: y}, nil, "x y"},
+		{"bound", Abstraction{Param: "x", Body: x}, map[string]Term{"x": z}, "λx.x"},
+		{"under binder", Abstraction{Param: "z", Body: Application{Func: x, Arg: y}}, map[string]Term{"x": y, "y": x}, "λz.y x"},
+		{"capture", Abstraction{Param: "y", Body: Application{Func: x, Arg: y}}, map[string]Term{"x": y}, "λy0.y y0"},
+		{"numeral", Numeral(2), map[string]Term{"x": y}, "[2]"},
+		{"numeral apply", NumeralApply{N: 2, Param: "y", F: x}, map[string]Term{"x": y}, "λy0.y^2 y0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SubstituteAll(tt.term, tt.subst)
+			if got.String() != tt.want {
+				t.Errorf("SubstituteAll(%s) = %s, want %s", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteAllDiffersFromSequential(t *testing.T) {
+	// Simultaneous {x→y, y→x} swaps, sequential substitution does not
+	term := Abstraction{Param: "z", Body: Application{Func: Var{Name: "x"}, Arg: Var{Name: "y"}}}
+
+	sequential := term.Substitute("x", Var{Name: "y"}).Substitute("y", Var{Name: "x"})
+	simultaneous := SubstituteAll(term, map[string]Term{"x": Var{Name: "y"}, "y": Var{Name: "x"}})
+
+	if sequential.String() != "λz.x x" {
+		t.Errorf("sequential = %s, want λz.x x", sequential)
+	}
+	if simultaneous.String() != "λz.y x" {
+		t.Errorf("simultaneous = %s, want λz.y x", simultaneous)
+	}
+}
+
+func TestSubstituteAvoiding(t *testing.T) {
+	// (λy.x z)[x := y] renames y to y0 with plain Substitute
+	term := Abstraction{Param: "y", Body: Application{Func: Var{Name: "x"}, Arg: Var{Name: "z"}}}
+	plain := term.Substitute("x", Var{Name: "y"})
+	if plain.String() != "λy0.y z" {
+		t.Fatalf("Substitute = %s, want λy0.y z", plain)
+	}
+
+	// A later transformation inserting a free y0 under the binder is
+	// captured by the generated name
+	insert := func(term Term) Term {
+		abs := term.(Abstraction)
+		return Abstraction{Param: abs.Param, Body: Application{Func: abs.Body, Arg: Var{Name: "y0"}}}
+	}
+	if fv := insert(plain).FreeVars(); fv["y0"] {
+		t.Fatalf("expected y0 to be captured in %s", insert(plain))
+	}
+
+	// Reserving y0 keeps it free
+	avoiding := SubstituteAvoiding(term, "x", Var{Name: "y"}, map[string]bool{"y0": true})
+	if avoiding.String() != "λy1.y z" {
+		t.Errorf("SubstituteAvoiding = %s, want λy1.y z", avoiding)
+	}
+	if fv := insert(avoiding).FreeVars(); !fv["y0"] || !fv["y"] {
+		t.Errorf("free variables of %s = %v, want y and y0", insert(avoiding), fv)
+	}
+
+	// Without capture, nothing is renamed
+	if got := SubstituteAvoiding(term, "x", Var{Name: "w"}, map[string]bool{"y": true}); got.String() != "λy.w z" {
+		t.Errorf("SubstituteAvoiding without capture = %s, want λy.w z", got)
+	}
+}
+
+func TestFreshen(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"λx.λx.x", "λx.λx0.x0"},
+		{"(λx.x) (λx.x)", "(λx.x) (λx0.x0)"},
+		{"(λx.x) x", "(λx0.x0) x"},
+		{"λx.λx.λx0.x x0", "λx.λx1.λx0.x1 x0"},
+		{"λf.λx.f x", "λf.λx.f x"},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		result := Freshen(term)
+		if result.String() != tt.want {
+			t.Errorf("Freshen(%s) = %s, want %s", tt.input, result, tt.want)
+		}
+		if !AlphaEquivalent(result, term) {
+			t.Errorf("Freshen(%s) = %s is not α-equivalent", tt.input, result)
+		}
+	}
+
+	// Every binder of a larger term gets its own name
+	result := Freshen(FACTORIAL)
+	if !AlphaEquivalent(result, FACTORIAL) {
+		t.Errorf("Freshen(FACTORIAL) = %s is not α-equivalent", result)
+	}
+	seen := make(map[string]bool)
+	Walk(result, func(sub Term, _ []int) bool {
+		if abs, ok := sub.(Abstraction); ok {
+			if seen[abs.Param] {
+				t.Errorf("binder %s appears twice in %s", abs.Param, result)
+			}
+			seen[abs.Param] = true
+		}
+		return true
+	})
+}
@@ -2,7 +2,9 @@ package lambda
 
 import (
 	"fmt"
+	"html"
 	"math"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +16,8 @@ const (
 	RectVariable                  // Vertical line from binding lambda to variable use
 	RectApp                       // Horizontal connector bar at bottom of application
 	RectConnector                 // Vertical connector from sub-term output to app bar
+	RectArrow                     // Horizontal arrow between combined diagrams, pointing right
+	RectNumeral                   // Box standing for a whole Church numeral, labeled with its value
 )
 
 // Color represents an RGB color.
@@ -41,17 +45,30 @@ type SVGDiagram struct {
 	GridWidth  int
 	GridHeight int
 	Rects      []SVGRect
+
+	// Binders maps the ID of the RectVariable of each bound variable to
+	// the ID of the RectLambda of its binder.
+	Binders map[int]int
+	// Names maps the ID of each RectVariable to the name of the variable,
+	// and of each RectLambda to the name of its parameter.
+	Names map[int]string
 }
 
 // SVGOptions controls rendering parameters.
 type SVGOptions struct {
-	CellSize   int               // Pixels per grid cell (default: 20)
-	Padding    int               // Padding around diagram in pixels (default: 10)
-	Background string            // Background color CSS string (default: "#000")
-	LineWidth  int               // Width of lines in pixels (default: CellSize/3)
-	Colors     map[int]Color     // Override colors by lambda index (pre-order)
-	Saturation float64           // Default HSV saturation 0-1 (default: 0.7)
-	Value      float64           // Default HSV value/brightness 0-1 (default: 1.0)
+	CellSize    int           // Pixels per grid cell (default: 20)
+	Padding     int           // Padding around diagram in pixels (default: 10)
+	Background  string        // Background color CSS string (default: "#000")
+	LineWidth   int           // Width of lines in pixels (default: CellSize/3)
+	Colors      map[int]Color // Override colors by lambda index (pre-order)
+	Saturation  float64       // Default HSV saturation 0-1 (default: 0.7)
+	Value       float64       // Default HSV value/brightness 0-1 (default: 1.0)
+	Trim        bool          // Crop the diagram to its drawing, with no padding
+	Transparent bool          // Omit the background rect, leaving the SVG transparent
+	Labels      bool          // Label each variable line with the variable's name
+	// CompactNumerals draws a term that is a Church numeral as a small box
+	// labeled with its value instead of its full structure
+	CompactNumerals bool
 }
 
 func (o *SVGOptions) cellSize() int {
@@ -62,6 +79,9 @@ func (o *SVGOptions) cellSize() int {
 }
 
 func (o *SVGOptions) padding() int {
+	if o != nil && o.Trim {
+		return 0
+	}
 	if o != nil && o.Padding > 0 {
 		return o.Padding
 	}
@@ -75,6 +95,16 @@ func (o *SVGOptions) background() string {
 	return "#000"
 }
 
+// writeBackground draws the background rect of a w×h image, unless the
+// image is transparent.
+func (o *SVGOptions) writeBackground(sb *strings.Builder, w, h int) {
+	if o != nil && o.Transparent {
+		return
+	}
+	fmt.Fprintf(sb, `<rect width="%d" height="%d" fill="%s"/>`, w, h, o.background())
+	sb.WriteByte('\n')
+}
+
 func (o *SVGOptions) lineWidth() int {
 	if o != nil && o.LineWidth > 0 {
 		return o.LineWidth
@@ -157,18 +187,85 @@ func blendColors(a, b Color) Color {
 
 // BuildSVGDiagram constructs the rect-based diagram for a term.
 func BuildSVGDiagram(term Term, opts *SVGOptions) *SVGDiagram {
+	if opts != nil && opts.CompactNumerals {
+		if n, ok := AsInt(term); ok {
+			return numeralDiagram(n, opts)
+		}
+	}
+
 	db := toDeBruijn(term, nil)
 	info := computeInfo(db)
 	numLambdas := countLambdas(db)
 
-	b := &svgBuilder{opts: opts, numLambdas: numLambdas}
+	b := &svgBuilder{
+		opts:       opts,
+		numLambdas: numLambdas,
+		binders:    make(map[int]int),
+		names:      make(map[int]string),
+	}
 	b.build(db, 0, 0, nil)
 
-	return &SVGDiagram{
+	d := &SVGDiagram{
 		GridWidth:  info.width,
 		GridHeight: info.height,
 		Rects:      b.rects,
+		Binders:    b.binders,
+		Names:      b.names,
+	}
+	if opts != nil && opts.Trim {
+		d.Trim()
+	}
+	return d
+}
+
+// numeralDiagram is the compact diagram of the numeral n: a box of fixed
+// size, whatever n, in the color of the outermost lambda.
+func numeralDiagram(n int, opts *SVGOptions) *SVGDiagram {
+	return &SVGDiagram{
+		GridWidth:  3,
+		GridHeight: 2,
+		Rects: []SVGRect{{
+			ID:     0,
+			Kind:   RectNumeral,
+			Width:  3,
+			Height: 2,
+			Color:  opts.colorFor(0, 2),
+		}},
+		Binders: make(map[int]int),
+		Names:   map[int]string{0: strconv.Itoa(n)},
+	}
+}
+
+// Trim crops the fully blank rows and columns at the edges of the grid,
+// moving the rects so that the drawing is unchanged. Diagrams built by
+// BuildSVGDiagram have none, but diagrams whose rects were edited or
+// combined may.
+func (d *SVGDiagram) Trim() {
+	if len(d.Rects) == 0 {
+		d.GridWidth, d.GridHeight = 0, 0
+		return
+	}
+	minRow, minCol := d.GridHeight, d.GridWidth
+	maxRow, maxCol := 0, 0
+	for _, r := range d.Rects {
+		lastRow, lastCol := r.Row, r.Col
+		if r.Height > 1 {
+			lastRow += r.Height - 1
+		}
+		if r.Width > 1 {
+			lastCol += r.Width - 1
+		}
+		minRow = min(minRow, r.Row)
+		minCol = min(minCol, r.Col)
+		maxRow = max(maxRow, lastRow)
+		maxCol = max(maxCol, lastCol)
 	}
+	for i := range d.Rects {
+		d.Rects[i].Row -= minRow
+		d.Rects[i].Col -= minCol
+	}
+	d.GridWidth = maxCol - minCol + 1
+	d.GridHeight = maxRow - minRow + 1
 }
 
 // DiagramSVG returns a static SVG string for a lambda term.
@@ -179,10 +276,18 @@ func DiagramSVG(term Term, opts *SVGOptions) string {
 
 // SVG renders the diagram to an SVG string.
 func (d *SVGDiagram) SVG(opts *SVGOptions) string {
-	return d.renderSVG(opts, "")
+	return d.renderSVG(opts, "", nil)
 }
 
-func (d *SVGDiagram) renderSVG(opts *SVGOptions, extraCSS string) string {
+// rectExtra returns the attributes to add to the element of a rect,
+// starting with a space, and the children to give it.
+type rectExtra func(r SVGRect) (attrs, children string)
+
+// renderSVG renders the diagram, with extraCSS in a style element. Each
+// rect gets the id r<ID>, unless extra is not nil: extra then gives its
+// attributes and children instead, and the rects have no id, so that
+// several diagrams can share a page.
+func (d *SVGDiagram) renderSVG(opts *SVGOptions, extraCSS string, extra rectExtra) string {
 	cs := opts.cellSize()
 	pad := opts.padding()
 	lw := opts.lineWidth()
@@ -199,21 +304,72 @@ func (d *SVGDiagram) renderSVG(opts *SVGOptions, extraCSS string) string {
 	}
 
 	// Background
-	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="%s"/>`, totalW, totalH, opts.background())
-	sb.WriteByte('\n')
+	opts.writeBackground(&sb, totalW, totalH)
 
 	// Draw each rect
 	for _, r := range d.Rects {
 		x, y, w, h := rectPixels(r, cs, pad, lw)
-		fmt.Fprintf(&sb, `<rect id="r%d" class="%s" x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`,
-			r.ID, rectClass(r.Kind), x, y, w, h, r.Color.css())
+		if extra == nil {
+			fmt.Fprintf(&sb, `<rect id="r%d" class="%s" x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`,
+				r.ID, rectClass(r.Kind), x, y, w, h, r.Color.css())
+		} else {
+			attrs, children := extra(r)
+			fmt.Fprintf(&sb, `<rect class="%s" x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"%s`,
+				rectClass(r.Kind), x, y, w, h, r.Color.css(), attrs)
+			if children == "" {
+				sb.WriteString("/>")
+			} else {
+				fmt.Fprintf(&sb, ">%s</rect>", children)
+			}
+		}
 		sb.WriteByte('\n')
+		d.writeDecoration(&sb, r, cs, pad, lw, opts)
+	}
+
+	if opts != nil && opts.Labels {
+		d.writeLabels(&sb, cs, pad, lw)
 	}
 
 	sb.WriteString("</svg>\n")
 	return sb.String()
 }
 
+// writeDecoration draws what a rect needs beyond its rectangle: the head
+// of an arrow or the value of a numeral.
+func (d *SVGDiagram) writeDecoration(sb *strings.Builder, r SVGRect, cs, pad, lw int, opts *SVGOptions) {
+	x, y, w, h := rectPixels(r, cs, pad, lw)
+	switch r.Kind {
+	case RectArrow:
+		// Arrowhead past the end of the shaft
+		fmt.Fprintf(sb, `<polygon class="arrow" points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"/>`,
+			x+w, y-float64(lw), x+w+float64(cs)/2, y+h/2, x+w, y+h+float64(lw), r.Color.css())
+		sb.WriteByte('\n')
+	case RectNumeral:
+		fmt.Fprintf(sb, `<text class="numeral" x="%.1f" y="%.1f" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`,
+			x+w/2, y+h/2, cs, opts.background(), html.EscapeString(d.Names[r.ID]))
+		sb.WriteByte('\n')
+	}
+}
+
+// writeLabels writes the name of each variable as a text label next to the
+// bottom end of its line, where the variable occurs in the term.
+func (d *SVGDiagram) writeLabels(sb *strings.Builder, cs, pad, lw int) {
+	size := cs / 2
+	if size < 1 {
+		size = 1
+	}
+	for _, r := range d.Rects {
+		name, ok := d.Names[r.ID]
+		if r.Kind != RectVariable || !ok {
+			continue
+		}
+		x, y, w, h := rectPixels(r, cs, pad, lw)
+		fmt.Fprintf(sb, `<text class="label" x="%.1f" y="%.1f" font-size="%d" fill="%s">%s</text>`,
+			x+w+1, y+h, size, r.Color.css(), html.EscapeString(name))
+		sb.WriteByte('\n')
+	}
+}
+
 // rectPixels computes pixel coordinates for a rect.
 // Horizontal bars span full cell width, centered vertically in their cell.
 // Vertical lines are centered horizontally and span from center of first cell to center of last cell,
@@ -221,7 +377,7 @@ func (d *SVGDiagram) renderSVG(opts *SVGOptions, extraCSS string) string {
 func rectPixels(r SVGRect, cs, pad, lw int) (x, y, w, h float64) {
 	offset := float64(cs-lw) / 2
 	switch r.Kind {
-	case RectLambda, RectApp:
+	case RectLambda, RectApp, RectArrow:
 		// Horizontal bar: full cell width, narrow height, vertically centered
 		x = float64(r.Col*cs + pad)
 		y = float64(r.Row*cs+pad) + offset
@@ -233,6 +389,12 @@ func rectPixels(r SVGRect, cs, pad, lw int) (x, y, w, h float64) {
 		y = float64(r.Row*cs+pad) + offset
 		w = float64(lw)
 		h = float64((r.Height-1)*cs) + float64(lw)
+	case RectNumeral:
+		// Box over all its cells
+		x = float64(r.Col*cs + pad)
+		y = float64(r.Row*cs + pad)
+		w = float64(r.Width * cs)
+		h = float64(r.Height * cs)
 	}
 	return
 }
@@ -247,6 +409,10 @@ func rectClass(k RectKind) string {
 		return "app"
 	case RectConnector:
 		return "conn"
+	case RectArrow:
+		return "arrow"
+	case RectNumeral:
+		return "numeral"
 	}
 	return ""
 }
@@ -271,12 +437,15 @@ type svgBuilder struct {
 	lambdaIdx  int // pre-order counter for lambda color assignment
 	nextID     int
 	rects      []SVGRect
+	binders    map[int]int
+	names      map[int]string
 }
 
 // lambdaInfo tracks binding lambda positions and colors during recursion.
 type lambdaInfo struct {
 	row   int
 	color Color
+	id    int // ID of the lambda bar rect
 }
 
 // buildResult holds the output point and color of a rendered sub-term.
@@ -299,6 +468,8 @@ func (b *svgBuilder) build(t dbTerm, topRow, leftCol int, lambdas []lambdaInfo)
 			lineRow := li.row
 			lineHeight := outRow - lineRow + 1
 			if lineHeight > 0 {
+				b.binders[b.nextID] = li.id
+				b.names[b.nextID] = term.name
 				b.rects = append(b.rects, SVGRect{
 					ID:     b.nextID,
 					Kind:   RectVariable,
@@ -312,6 +483,7 @@ func (b *svgBuilder) build(t dbTerm, topRow, leftCol int, lambdas []lambdaInfo)
 		} else {
 			// Free variable — use grey
 			color = Color{128, 128, 128}
+			b.names[b.nextID] = term.name
 			b.rects = append(b.rects, SVGRect{
 				ID:     b.nextID,
 				Kind:   RectVariable,
@@ -331,6 +503,8 @@ func (b *svgBuilder) build(t dbTerm, topRow, leftCol int, lambdas []lambdaInfo)
 		b.lambdaIdx++
 
 		// Lambda bar rect
+		lambdaID := b.nextID
+		b.names[lambdaID] = term.param
 		b.rects = append(b.rects, SVGRect{
 			ID:    b.nextID,
 			Kind:  RectLambda,
@@ -342,7 +516,7 @@ func (b *svgBuilder) build(t dbTerm, topRow, leftCol int, lambdas []lambdaInfo)
 		b.nextID++
 
 		// Recurse into body
-		newLambdas := append(lambdas, lambdaInfo{row: topRow, color: lambdaColor})
+		newLambdas := append(lambdas, lambdaInfo{row: topRow, color: lambdaColor, id: lambdaID})
 		result := b.build(term.body, topRow+1, leftCol, newLambdas)
 		return buildResult{result.outRow, result.outCol, result.color}
 
@@ -126,6 +126,13 @@ func renderAnimatedSVG(frames []animFrame, opts *AnimationOptions) string {
 		}
 	}
 
+	// Text and arrowheads are not morphed like the rects: each frame draws
+	// its own, shown only while the rects are in that frame's state.
+	overlays := make([]string, len(frames))
+	for fi, f := range frames {
+		overlays[fi] = frameOverlay(f.diagram, svgOpts, cs, pad, lw)
+	}
+
 	var sb strings.Builder
 	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d">`, totalW, totalH, totalW, totalH)
 	sb.WriteByte('\n')
@@ -178,11 +185,35 @@ func renderAnimatedSVG(frames []animFrame, opts *AnimationOptions) string {
 		sb.WriteString("}\n")
 	}
 
+	for fi, overlay := range overlays {
+		if overlay == "" {
+			continue
+		}
+		animName := fmt.Sprintf("o%d", fi)
+		fmt.Fprintf(&sb, "#f%d { animation: %s %.2fs ease-in-out %s; animation-fill-mode: %s; }\n",
+			fi, animName, totalDur, iterCount, fillMode)
+		fmt.Fprintf(&sb, "@keyframes %s {\n", animName)
+		holdStart := float64(fi) * (stepDur + pauseDur)
+		holdEnd := holdStart + pauseDur
+		if fi > 0 {
+			// Fade in during the transition from the previous frame
+			fmt.Fprintf(&sb, "  0.00%% { opacity: 0 }\n")
+			fmt.Fprintf(&sb, "  %.2f%% { opacity: 0 }\n", (holdStart-stepDur)/totalDur*100)
+		}
+		fmt.Fprintf(&sb, "  %.2f%% { opacity: 1 }\n", holdStart/totalDur*100)
+		if fi < len(frames)-1 {
+			// Fade out during the transition to the next frame
+			fmt.Fprintf(&sb, "  %.2f%% { opacity: 1 }\n", holdEnd/totalDur*100)
+			fmt.Fprintf(&sb, "  %.2f%% { opacity: 0 }\n", (holdEnd+stepDur)/totalDur*100)
+			fmt.Fprintf(&sb, "  100.00%% { opacity: 0 }\n")
+		}
+		sb.WriteString("}\n")
+	}
+
 	sb.WriteString("</style>\n")
 
 	// Background
-	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="%s"/>`, totalW, totalH, svgOpts.background())
-	sb.WriteByte('\n')
+	svgOpts.writeBackground(&sb, totalW, totalH)
 
 	// Draw rects from the first frame as initial state
 	for i := 0; i < maxRects; i++ {
@@ -197,10 +228,35 @@ func renderAnimatedSVG(frames []animFrame, opts *AnimationOptions) string {
 		sb.WriteByte('\n')
 	}
 
+	// Draw the overlays, only the first frame's visible without animation
+	for fi, overlay := range overlays {
+		if overlay == "" {
+			continue
+		}
+		if fi == 0 {
+			fmt.Fprintf(&sb, "<g id=\"f%d\">\n%s</g>\n", fi, overlay)
+		} else {
+			fmt.Fprintf(&sb, "<g id=\"f%d\" opacity=\"0\">\n%s</g>\n", fi, overlay)
+		}
+	}
+
 	sb.WriteString("</svg>\n")
 	return sb.String()
 }
 
+// frameOverlay returns what a frame draws beyond its rects: arrowheads,
+// numeral values and, with Labels, variable names.
+func frameOverlay(d *SVGDiagram, opts *SVGOptions, cs, pad, lw int) string {
+	var sb strings.Builder
+	for _, r := range d.Rects {
+		d.writeDecoration(&sb, r, cs, pad, lw, opts)
+	}
+	if opts != nil && opts.Labels {
+		d.writeLabels(&sb, cs, pad, lw)
+	}
+	return sb.String()
+}
+
 // rectAnimProps returns a CSS property string for rect i in the given diagram.
 func rectAnimProps(i int, d *SVGDiagram, cs, pad, lw int) string {
 	if i < len(d.Rects) {
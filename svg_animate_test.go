@@ -138,3 +138,30 @@ func TestAnimatedSVGColors(t *testing.T) {
 		t.Error("custom color not found in animated SVG")
 	}
 }
+
+func TestAnimatedSVGCompactNumerals(t *testing.T) {
+	term, err := Parse("_SUCC _2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &AnimationOptions{SVGOptions: SVGOptions{CompactNumerals: true}}
+	svg := DiagramAnimatedSVG(term, opts)
+	if !strings.Contains(svg, `class="numeral"`) || !strings.Contains(svg, ">3</text>") {
+		t.Errorf("animated SVG of SUCC 2 does not show the value 3:\n%s", svg)
+	}
+}
+
+func TestAnimatedSVGLabels(t *testing.T) {
+	term := Application{
+		Func: Abstraction{Param: "x", Body: Var{Name: "x"}},
+		Arg:  Var{Name: "y"},
+	}
+	opts := &AnimationOptions{SVGOptions: SVGOptions{Labels: true}}
+	svg := DiagramAnimatedSVG(term, opts)
+	// x is labeled in the first frame and y in the last one
+	for _, want := range []string{`<g id="f0">`, ">x</text>", `<g id="f1" opacity="0">`, ">y</text>", "#f1 {"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("animated SVG with labels lacks %s:\n%s", want, svg)
+		}
+	}
+}
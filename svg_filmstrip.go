@@ -0,0 +1,72 @@
+package lambda
+
+// maxFilmstripFrames caps the number of diagrams ReductionFilmstrip
+// generates, as a runaway reduction would otherwise produce one per step.
+const maxFilmstripFrames = 100
+
+// ReductionFilmstrip reduces obj like Reduce, at most limit steps, and
+// returns the diagram of obj followed by the diagram after each step, as
+// DiagramAnimatedFrames does. At most 100 diagrams are returned, whatever
+// the limit. Lay them out with CombineHorizontally to show how the term
+// evolves in a single image.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func ReductionFilmstrip(obj Term, limit int, opts *SVGOptions) []*SVGDiagram {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	return DiagramAnimatedFrames(obj, opts, min(limit, maxFilmstripFrames-1))
+}
+
+// CombineHorizontally lays diagrams out side by side, aligned at the top,
+// with gap grid cells between consecutive diagrams and an arrow in each
+// gap, pointing to the next diagram. The gap is at least 3 cells, to fit
+// the arrow. Rect IDs are renumbered in order; Binders and Names follow.
+func CombineHorizontally(diagrams []*SVGDiagram, gap int) *SVGDiagram {
+	if gap < 3 {
+		gap = 3
+	}
+	combined := &SVGDiagram{
+		Binders: make(map[int]int),
+		Names:   make(map[int]string),
+	}
+	for _, d := range diagrams {
+		combined.GridHeight = max(combined.GridHeight, d.GridHeight)
+	}
+
+	col := 0
+	for i, d := range diagrams {
+		if i > 0 {
+			combined.Rects = append(combined.Rects, SVGRect{
+				ID:    len(combined.Rects),
+				Kind:  RectArrow,
+				Row:   (combined.GridHeight - 1) / 2,
+				Col:   col + 1,
+				Width: gap - 2,
+				Color: Color{200, 200, 200},
+			})
+			col += gap
+		}
+
+		// Renumber the rects of d after those already placed
+		base := len(combined.Rects)
+		ids := make(map[int]int, len(d.Rects))
+		for j, r := range d.Rects {
+			ids[r.ID] = base + j
+		}
+		for _, r := range d.Rects {
+			id := ids[r.ID]
+			if binder, ok := d.Binders[r.ID]; ok {
+				combined.Binders[id] = ids[binder]
+			}
+			if name, ok := d.Names[r.ID]; ok {
+				combined.Names[id] = name
+			}
+			r.ID = id
+			r.Col += col
+			combined.Rects = append(combined.Rects, r)
+		}
+		col += d.GridWidth
+	}
+	combined.GridWidth = col
+	return combined
+}
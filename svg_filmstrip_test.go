@@ -0,0 +1,68 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReductionFilmstrip(t *testing.T) {
+	// (λx.x) y → y
+	term := Application{Func: I, Arg: Var{Name: "y"}}
+	frames := ReductionFilmstrip(term, 0, nil)
+	if len(frames) != 2 {
+		t.Fatalf("ReductionFilmstrip gave %d frames, want 2", len(frames))
+	}
+	if frames[1].GridWidth != 1 || frames[1].GridHeight != 1 {
+		t.Errorf("last frame is %dx%d, want the 1x1 diagram of y", frames[1].GridWidth, frames[1].GridHeight)
+	}
+
+	// Runaway reductions are capped
+	if n := len(ReductionFilmstrip(OMEGA, 1000, nil)); n != maxFilmstripFrames {
+		t.Errorf("ReductionFilmstrip(Ω) gave %d frames, want %d", n, maxFilmstripFrames)
+	}
+}
+
+func TestCombineHorizontally(t *testing.T) {
+	frames := ReductionFilmstrip(Application{Func: I, Arg: Var{Name: "y"}}, 0, nil)
+	first := frames[0]
+	d := CombineHorizontally(frames, 4)
+
+	if d.GridWidth != first.GridWidth+4+1 || d.GridHeight != first.GridHeight {
+		t.Errorf("combined grid is %dx%d", d.GridWidth, d.GridHeight)
+	}
+	if len(d.Rects) != len(first.Rects)+1+len(frames[1].Rects) {
+		t.Fatalf("combined diagram has %d rects", len(d.Rects))
+	}
+	for i, r := range d.Rects {
+		if r.ID != i {
+			t.Errorf("rect %d has ID %d", i, r.ID)
+		}
+	}
+
+	arrow := d.Rects[len(first.Rects)]
+	if arrow.Kind != RectArrow || arrow.Col != first.GridWidth+1 || arrow.Width != 2 {
+		t.Errorf("arrow = %+v", arrow)
+	}
+	last := d.Rects[len(d.Rects)-1]
+	if last.Col != first.GridWidth+4 {
+		t.Errorf("second diagram starts at column %d, want %d", last.Col, first.GridWidth+4)
+	}
+
+	// Bindings follow the renumbering
+	for v, l := range d.Binders {
+		if d.Rects[v].Kind != RectVariable || d.Rects[l].Kind != RectLambda {
+			t.Errorf("binding %d → %d does not link a variable to a lambda", v, l)
+		}
+	}
+	if len(d.Binders) != len(first.Binders)+len(frames[1].Binders) {
+		t.Errorf("Binders = %v", d.Binders)
+	}
+
+	svg := d.SVG(nil)
+	if !strings.Contains(svg, `class="arrow"`) || !strings.Contains(svg, "<polygon") {
+		t.Errorf("combined SVG has no arrow:\n%s", svg)
+	}
+	if tikz := ToTikZ(d); !strings.Contains(tikz, "->") {
+		t.Errorf("combined TikZ has no arrow:\n%s", tikz)
+	}
+}
@@ -0,0 +1,77 @@
+package lambda
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// htmlDiagramCSS dims the diagram while a binding is hovered, except for
+// the binder and the variables it binds.
+const htmlDiagramCSS = `.lambda-diagram svg.binding rect { opacity: 0.25; }
+.lambda-diagram svg.binding rect.bound { opacity: 1; }
+`
+
+// htmlDiagramScript marks the rects sharing the data-binder of the hovered
+// one, so that hovering a variable highlights its lambda and the other
+// occurrences of the variable, and hovering a lambda highlights its
+// variables.
+const htmlDiagramScript = `(function(svg) {
+  svg.addEventListener("mouseover", function(e) {
+    var b = e.target.getAttribute && e.target.getAttribute("data-binder");
+    if (!b) return;
+    svg.classList.add("binding");
+    svg.querySelectorAll('[data-binder="' + b + '"]').forEach(function(r) { r.classList.add("bound"); });
+  });
+  svg.addEventListener("mouseout", function() {
+    svg.classList.remove("binding");
+    svg.querySelectorAll(".bound").forEach(function(r) { r.classList.remove("bound"); });
+  });
+})(document.currentScript.previousElementSibling);
+`
+
+// DiagramHTML returns an HTML fragment showing the diagram of term as an
+// interactive SVG: hovering a variable highlights the lambda binding it,
+// and hovering a lambda highlights its variables. Each lambda and variable
+// also has a tooltip with its name. DiagramSVG renders the same diagram
+// without interactivity.
+func DiagramHTML(term Term, opts *SVGOptions) string {
+	return BuildSVGDiagram(term, opts).HTML(opts)
+}
+
+// HTML renders the diagram like SVG, as an HTML fragment with the binding
+// of each variable in the data-binder attribute of its rect: a key naming
+// the binding lambda's rect, which carries the same attribute. A small
+// inline script highlights a binding on hover. The rects have no id, so a
+// page may show several diagrams.
+func (d *SVGDiagram) HTML(opts *SVGOptions) string {
+	svg := d.renderSVG(opts, htmlDiagramCSS, func(r SVGRect) (attrs, children string) {
+		var binder, title string
+		switch r.Kind {
+		case RectLambda:
+			binder = fmt.Sprintf("r%d", r.ID)
+			title = "λ" + d.Names[r.ID]
+		case RectVariable:
+			if id, ok := d.Binders[r.ID]; ok {
+				binder = fmt.Sprintf("r%d", id)
+				title = d.Names[r.ID] + ", bound by λ" + d.Names[id]
+			} else {
+				title = d.Names[r.ID] + ", free"
+			}
+		}
+		if binder != "" {
+			attrs = fmt.Sprintf(` data-binder="%s"`, binder)
+		}
+		if title != "" {
+			children = "<title>" + html.EscapeString(title) + "</title>"
+		}
+		return attrs, children
+	})
+
+	var sb strings.Builder
+	sb.WriteString("<div class=\"lambda-diagram\">\n")
+	sb.WriteString(svg)
+	fmt.Fprintf(&sb, "<script>\n%s</script>\n", htmlDiagramScript)
+	sb.WriteString("</div>\n")
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagramHTML(t *testing.T) {
+	// K z: x is bound by the first lambda, z is free
+	got := DiagramHTML(Application{Func: K, Arg: Var{Name: "z"}}, nil)
+
+	for _, want := range []string{
+		`<div class="lambda-diagram">`,
+		`class="lam" x="10.0" y="17.0" width="20.0" height="6.0" fill="rgb(255,77,77)" data-binder="r0"><title>λx</title>`,
+		`class="var" x="17.0" y="17.0" width="6.0" height="46.0" fill="rgb(255,77,77)" data-binder="r0"><title>x, bound by λx</title>`,
+		`<title>z, free</title>`,
+		`<script>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DiagramHTML(K z) does not contain %s:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, `data-binder="r0"`); n != 2 {
+		t.Errorf("data-binder=r0 appears %d times, want 2", n)
+	}
+
+	// No ids, which would collide between diagrams on the same page
+	if strings.Contains(got, ` id="`) {
+		t.Errorf("DiagramHTML(K z) has ids:\n%s", got)
+	}
+
+	// Names are escaped
+	got = DiagramHTML(Var{Name: "<a>"}, nil)
+	if !strings.Contains(got, "<title>&lt;a&gt;, free</title>") {
+		t.Errorf("DiagramHTML(<a>) does not escape the name:\n%s", got)
+	}
+
+	// The plain SVG has no binding attributes
+	if svg := DiagramSVG(K, nil); strings.Contains(svg, "data-binder") || strings.Contains(svg, "<title>") {
+		t.Errorf("DiagramSVG changed:\n%s", svg)
+	}
+}
+
+func TestSVGDiagramBinders(t *testing.T) {
+	// λx.λy.x y: both variables are bound, each by its own lambda
+	d := BuildSVGDiagram(Abstraction{Param: "x", Body: Abstraction{Param: "y", Body: Application{Func: Var{Name: "x"}, Arg: Var{Name: "y"}}}}, nil)
+	bound := map[string]string{}
+	for varID, lamID := range d.Binders {
+		bound[d.Names[varID]] = d.Names[lamID]
+	}
+	if len(bound) != 2 || bound["x"] != "x" || bound["y"] != "y" {
+		t.Errorf("Binders = %v, Names = %v", d.Binders, d.Names)
+	}
+}
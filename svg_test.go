@@ -192,3 +192,99 @@ func TestSVGWellFormed(t *testing.T) {
 		})
 	}
 }
+
+func TestSVGTrim(t *testing.T) {
+	// The identity diagram loses its padding
+	padded := DiagramSVG(I, nil)
+	trimmed := DiagramSVG(I, &SVGOptions{Trim: true})
+	if !strings.Contains(padded, `width="40" height="60"`) {
+		t.Errorf("padded identity diagram:\n%s", padded)
+	}
+	if !strings.Contains(trimmed, `width="20" height="40"`) {
+		t.Errorf("trimmed identity diagram:\n%s", trimmed)
+	}
+	if strings.Count(trimmed, "<rect") != strings.Count(padded, "<rect") {
+		t.Errorf("trimming changed the drawing:\n%s", trimmed)
+	}
+
+	// Blank rows and columns around the drawing are cropped
+	d := BuildSVGDiagram(I, nil)
+	want := append([]SVGRect{}, d.Rects...)
+	for i := range d.Rects {
+		d.Rects[i].Row += 2
+		d.Rects[i].Col += 3
+	}
+	d.GridWidth += 5
+	d.GridHeight += 4
+	d.Trim()
+	if d.GridWidth != 1 || d.GridHeight != 2 {
+		t.Errorf("Trim() gives a %dx%d grid, want 1x2", d.GridWidth, d.GridHeight)
+	}
+	for i := range want {
+		if d.Rects[i] != want[i] {
+			t.Errorf("Trim() moved rect %d to %+v, want %+v", i, d.Rects[i], want[i])
+		}
+	}
+}
+
+func TestSVGTransparent(t *testing.T) {
+	background := `<rect width="40" height="60" fill="#000"/>`
+	if svg := DiagramSVG(I, nil); !strings.Contains(svg, background) {
+		t.Errorf("default SVG has no background rect:\n%s", svg)
+	}
+	opts := &SVGOptions{Transparent: true, CellSize: 40, LineWidth: 4}
+	svg := DiagramSVG(I, opts)
+	if strings.Contains(svg, `fill="#000"`) {
+		t.Errorf("transparent SVG has a background:\n%s", svg)
+	}
+	// The bar of I spans one 40px cell, 4px high
+	if !strings.Contains(svg, `width="40.0" height="4.0"`) {
+		t.Errorf("cell size and line width not applied:\n%s", svg)
+	}
+	if anim := DiagramAnimatedSVG(Application{Func: I, Arg: I}, &AnimationOptions{SVGOptions: *opts}); strings.Contains(anim, `fill="#000"`) {
+		t.Errorf("transparent animated SVG has a background")
+	}
+}
+
+func TestSVGLabels(t *testing.T) {
+	if svg := DiagramSVG(ChurchNumeral(2), nil); strings.Contains(svg, "<text") {
+		t.Errorf("labels drawn by default:\n%s", svg)
+	}
+
+	// λf.λx.f (f x): two f lines and one x line
+	svg := DiagramSVG(ChurchNumeral(2), &SVGOptions{Labels: true})
+	if n := strings.Count(svg, `class="label"`); n != 3 {
+		t.Errorf("%d labels, want 3:\n%s", n, svg)
+	}
+	if strings.Count(svg, ">f</text>") != 2 || strings.Count(svg, ">x</text>") != 1 {
+		t.Errorf("wrong labels:\n%s", svg)
+	}
+	if !strings.Contains(DiagramHTML(ChurchNumeral(2), &SVGOptions{Labels: true}), ">x</text>") {
+		t.Errorf("HTML diagram has no labels")
+	}
+}
+
+func TestSVGCompactNumerals(t *testing.T) {
+	opts := &SVGOptions{CompactNumerals: true}
+	for _, n := range []int{0, 3, 10} {
+		d := BuildSVGDiagram(ChurchNumeral(n), opts)
+		if d.GridWidth != 3 || d.GridHeight != 2 || len(d.Rects) != 1 || d.Rects[0].Kind != RectNumeral {
+			t.Errorf("numeral %d: %dx%d grid with %d rects, want one numeral box on 3x2",
+				n, d.GridWidth, d.GridHeight, len(d.Rects))
+		}
+	}
+
+	svg := DiagramSVG(ChurchNumeral(3), opts)
+	if !strings.Contains(svg, `class="numeral"`) || !strings.Contains(svg, ">3</text>") {
+		t.Errorf("compact numeral not drawn:\n%s", svg)
+	}
+
+	// Without the option, and for terms that are not numerals, the full
+	// structure is drawn
+	if d := BuildSVGDiagram(ChurchNumeral(3), nil); len(d.Rects) == 1 {
+		t.Errorf("numeral drawn compactly by default")
+	}
+	if d := BuildSVGDiagram(I, opts); d.Rects[0].Kind == RectNumeral {
+		t.Errorf("identity drawn as a numeral")
+	}
+}
@@ -0,0 +1,53 @@
+package lambda
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToTikZ renders the diagram as a TikZ picture for embedding in LaTeX
+// documents. Each grid cell is one TikZ unit; rows grow downwards. Lines
+// are drawn through the cell centers like the bars and lines of the SVG
+// output, in the same colors.
+func ToTikZ(d *SVGDiagram) string {
+	var sb strings.Builder
+	sb.WriteString("\\begin{tikzpicture}[y=-1cm, line width=0.3cm]\n")
+
+	for _, r := range d.Rects {
+		var x1, y1, x2, y2 float64
+		style := ""
+		switch r.Kind {
+		case RectLambda, RectApp:
+			// Horizontal bar across the full width of its cells
+			x1 = float64(r.Col)
+			x2 = float64(r.Col + r.Width)
+			y1 = float64(r.Row) + 0.5
+			y2 = y1
+		case RectNumeral:
+			fmt.Fprintf(&sb, "  \\draw[color={rgb,255:red,%d;green,%d;blue,%d}, line width=0.05cm] (%d,%d) rectangle (%d,%d) node[midway] {%s}; %% %s\n",
+				r.Color.R, r.Color.G, r.Color.B, r.Col, r.Row, r.Col+r.Width, r.Row+r.Height, d.Names[r.ID], rectClass(r.Kind))
+			continue
+		case RectArrow:
+			x1 = float64(r.Col)
+			x2 = float64(r.Col+r.Width) + 0.5
+			y1 = float64(r.Row) + 0.5
+			y2 = y1
+			style = ", ->"
+		case RectVariable, RectConnector:
+			// Vertical line from the center of the first to the center of the last row
+			x1 = float64(r.Col) + 0.5
+			x2 = x1
+			y1 = float64(r.Row) + 0.5
+			y2 = float64(r.Row+r.Height-1) + 0.5
+			// Extend past the centers to overlap the bars they connect
+			style = ", line cap=rect"
+		default:
+			continue
+		}
+		fmt.Fprintf(&sb, "  \\draw[color={rgb,255:red,%d;green,%d;blue,%d}%s] (%g,%g) -- (%g,%g); %% %s\n",
+			r.Color.R, r.Color.G, r.Color.B, style, x1, y1, x2, y2, rectClass(r.Kind))
+	}
+
+	sb.WriteString("\\end{tikzpicture}\n")
+	return sb.String()
+}
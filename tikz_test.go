@@ -0,0 +1,42 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToTikZIdentity(t *testing.T) {
+	d := BuildSVGDiagram(I, nil)
+	tikz := ToTikZ(d)
+
+	if !strings.HasPrefix(tikz, "\\begin{tikzpicture}") {
+		t.Errorf("missing tikzpicture environment:\n%s", tikz)
+	}
+	if !strings.HasSuffix(tikz, "\\end{tikzpicture}\n") {
+		t.Errorf("missing end of tikzpicture environment:\n%s", tikz)
+	}
+	if n := strings.Count(tikz, "\\draw"); n != len(d.Rects) {
+		t.Errorf("got %d \\draw commands, want one per rect (%d):\n%s", n, len(d.Rects), tikz)
+	}
+}
+
+func TestToTikZCoordinates(t *testing.T) {
+	d := &SVGDiagram{
+		GridWidth:  2,
+		GridHeight: 3,
+		Rects: []SVGRect{
+			{Kind: RectLambda, Row: 0, Col: 0, Width: 2, Height: 1, Color: Color{255, 0, 0}},
+			{Kind: RectVariable, Row: 0, Col: 1, Width: 1, Height: 3, Color: Color{0, 0, 255}},
+		},
+	}
+	tikz := ToTikZ(d)
+
+	for _, want := range []string{
+		"\\draw[color={rgb,255:red,255;green,0;blue,0}] (0,0.5) -- (2,0.5);",
+		"\\draw[color={rgb,255:red,0;green,0;blue,255}, line cap=rect] (1.5,0.5) -- (1.5,2.5);",
+	} {
+		if !strings.Contains(tikz, want) {
+			t.Errorf("missing %q in:\n%s", want, tikz)
+		}
+	}
+}
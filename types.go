@@ -0,0 +1,151 @@
+package lambda
+
+import (
+	"fmt"
+)
+
+// Simple types
+//
+// A simple type is either a base type variable such as a, or an arrow type
+// A→B of functions from A to B. There is no type inference yet; types are
+// given by the caller.
+
+// Type is a simple type: a TypeVar or an Arrow.
+type Type interface {
+	String() string
+	isType()
+}
+
+// TypeVar is a base type.
+type TypeVar struct {
+	Name string
+}
+
+// Arrow is the type of functions from From to To.
+type Arrow struct {
+	From Type
+	To   Type
+}
+
+func (TypeVar) isType() {}
+func (Arrow) isType()   {}
+
+func (v TypeVar) String() string {
+	return v.Name
+}
+
+func (a Arrow) String() string {
+	// Arrows associate to the right: a→b→c is a→(b→c)
+	if _, ok := a.From.(Arrow); ok {
+		return fmt.Sprintf("(%s)→%s", a.From, a.To)
+	}
+	return fmt.Sprintf("%s→%s", a.From, a.To)
+}
+
+// LongNF reduces the closed term t to β-normal form in at most limit steps
+// and η-expands it according to ty, giving its η-long β-normal form: every
+// subterm of arrow type is an abstraction and every variable is applied to
+// all the arguments its type allows. Two terms of type ty are βη-equal
+// exactly when their long normal forms are α-equivalent.
+//
+// An error is returned if no normal form is reached, if t has free
+// variables, or if it does not have type ty.
+// If limit is 0 or negative, DefaultStepLimit is used.
+func LongNF(t Term, ty Type, limit int) (Term, error) {
+	if limit <= 0 {
+		limit = DefaultStepLimit
+	}
+	if !IsClosed(t) {
+		return nil, &ClosedError{Free: FreeVarNames(t)}
+	}
+	nf, ok := normalForm(t, limit)
+	if !ok {
+		return nil, ErrNotNormalized
+	}
+	names := make(map[string]bool)
+	varNames(nf, names)
+	l := &longNF{names: names}
+	return l.expand(nf, ty, nil)
+}
+
+// longNF holds the state of one LongNF computation.
+type longNF struct {
+	names map[string]bool // names in use, avoided for fresh variables
+}
+
+// typeBinding gives the type of a bound variable.
+type typeBinding struct {
+	name string
+	ty   Type
+	next *typeBinding
+}
+
+func (b *typeBinding) lookup(name string) (Type, bool) {
+	for ; b != nil; b = b.next {
+		if b.name == name {
+			return b.ty, true
+		}
+	}
+	return nil, false
+}
+
+// expand η-expands the β-normal term t at type ty, where ctx gives the
+// types of the enclosing binders.
+func (l *longNF) expand(t Term, ty Type, ctx *typeBinding) (Term, error) {
+	t = unwrap(t)
+	if arrow, ok := ty.(Arrow); ok {
+		if abs, ok := asAbstraction(t); ok {
+			body, err := l.expand(abs.Body, arrow.To, &typeBinding{name: abs.Param, ty: arrow.From, next: ctx})
+			if err != nil {
+				return nil, err
+			}
+			return Abstraction{Param: abs.Param, Body: body}, nil
+		}
+		// t is neutral: expand to λx.t x
+		x := freshVar("x", l.names)
+		l.names[x] = true
+		body, err := l.expand(Application{Func: t, Arg: Var{Name: x}}, arrow.To, &typeBinding{name: x, ty: arrow.From, next: ctx})
+		if err != nil {
+			return nil, err
+		}
+		return Abstraction{Param: x, Body: body}, nil
+	}
+
+	// At a base type, t must be a variable applied to arguments
+	var args []Term
+	head := t
+	for {
+		app, ok := head.(Application)
+		if !ok {
+			break
+		}
+		args = append(args, app.Arg)
+		head = unwrap(app.Func)
+	}
+	v, ok := head.(Var)
+	if !ok {
+		return nil, fmt.Errorf("%s does not have type %s", t, ty)
+	}
+	headTy, ok := ctx.lookup(v.Name)
+	if !ok {
+		return nil, fmt.Errorf("unbound variable %s", v.Name)
+	}
+
+	var result Term = v
+	for i := len(args) - 1; i >= 0; i-- {
+		arrow, ok := headTy.(Arrow)
+		if !ok {
+			return nil, fmt.Errorf("%s is applied to too many arguments for type %s", v.Name, headTy)
+		}
+		arg, err := l.expand(args[i], arrow.From, ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = Application{Func: result, Arg: arg}
+		headTy = arrow.To
+	}
+	if headTy != ty {
+		return nil, fmt.Errorf("%s has type %s, not %s", t, headTy, ty)
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestLongNF(t *testing.T) {
+	a, b := TypeVar{Name: "a"}, TypeVar{Name: "b"}
+	aa := Arrow{From: a, To: a}
+	ab := Arrow{From: a, To: b}
+
+	tests := []struct {
+		input    string
+		ty       Type
+		expected string
+	}{
+		{`\x.x`, aa, `\x.x`},
+		{`\x.(\y.y) x`, aa, `\x.x`},
+		{`\f.f`, Arrow{From: ab, To: ab}, `\f.\x.f x`},
+		{`\f.\x.f x`, Arrow{From: ab, To: ab}, `\f.\x.f x`},
+		{`\x.x`, Arrow{From: ab, To: ab}, `\x.\x0.x x0`},
+		{`_2`, Arrow{From: aa, To: aa}, `\f.\x.f (f x)`},
+		{`\f.\x.f x`, Arrow{From: Arrow{From: aa, To: a}, To: Arrow{From: aa, To: a}}, `\f.\x.f (\x0.x x0)`},
+	}
+
+	for _, tt := range tests {
+		term, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		long, err := LongNF(term, tt.ty, 0)
+		if err != nil {
+			t.Errorf("LongNF(%s, %s): %v", tt.input, tt.ty, err)
+			continue
+		}
+		expected, _ := Parse(tt.expected)
+		if !AlphaEquivalent(long, expected) {
+			t.Errorf("LongNF(%s, %s) = %s, expected %s", tt.input, tt.ty, long, tt.expected)
+		}
+	}
+}
+
+func TestLongNFEtaEqual(t *testing.T) {
+	ty := Arrow{From: Arrow{From: TypeVar{Name: "a"}, To: TypeVar{Name: "b"}}, To: Arrow{From: TypeVar{Name: "a"}, To: TypeVar{Name: "b"}}}
+	f, _ := Parse(`\f.f`)
+	g, _ := Parse(`\g.\y.g y`)
+	x, _ := LongNF(f, ty, 0)
+	y, _ := LongNF(g, ty, 0)
+	if !AlphaEquivalent(x, y) {
+		t.Errorf("η-equal terms have different long normal forms: %s and %s", x, y)
+	}
+}
+
+func TestLongNFErrors(t *testing.T) {
+	a := TypeVar{Name: "a"}
+	tests := []struct {
+		input string
+		ty    Type
+	}{
+		{`x`, a},                          // free variable
+		{`\x.x`, a},                       // abstraction at base type
+		{`\x.x x`, Arrow{From: a, To: a}}, // a applied as a function
+		{`(\x.x x) (\x.x x)`, Arrow{From: a, To: a}}, // no normal form
+	}
+	for _, tt := range tests {
+		term, _ := Parse(tt.input)
+		if _, err := LongNF(term, tt.ty, 100); err == nil {
+			t.Errorf("LongNF(%s, %s) should fail", tt.input, tt.ty)
+		}
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	a, b := TypeVar{Name: "a"}, TypeVar{Name: "b"}
+	ty := Arrow{From: Arrow{From: a, To: b}, To: Arrow{From: a, To: b}}
+	if got := ty.String(); got != "(a→b)→a→b" {
+		t.Errorf("String() = %q", got)
+	}
+}
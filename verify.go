@@ -0,0 +1,164 @@
+package lambda
+
+import (
+	"fmt"
+)
+
+// constantCheck is a documented property of the package constants: expr
+// and want must reduce to α-equivalent normal forms. Both are parsed, so
+// they can use the _NAME constants and free variables as placeholders.
+type constantCheck struct {
+	expr, want string
+}
+
+// verifyStepLimit bounds the reduction of each side of a check
+const verifyStepLimit = 20000
+
+// constantChecks lists the properties verified by VerifyConstants
+var constantChecks = []constantCheck{
+	// Combinators
+	{`_I x`, `x`},
+	{`_K a b`, `a`},
+	{`_S f g x`, `f x (g x)`},
+	{`_B f g x`, `f (g x)`},
+	{`_C f x y`, `f y x`},
+	{`_W f x`, `f x x`},
+	{`_U x`, `x x`},
+
+	// Combinator birds
+	{`_THRUSH x f`, `f x`},
+	{`_ROBIN x y z`, `y z x`},
+	{`_LARK x y`, `x (y y)`},
+	{`_VIREO x y f`, `f x y`},
+
+	// Booleans
+	{`_TRUE a b`, `a`},
+	{`_FALSE a b`, `b`},
+	{`_AND _TRUE _FALSE`, `_FALSE`},
+	{`_AND _TRUE _TRUE`, `_TRUE`},
+	{`_OR _FALSE _TRUE`, `_TRUE`},
+	{`_OR _FALSE _FALSE`, `_FALSE`},
+	{`_NOT _TRUE`, `_FALSE`},
+	{`_NOT _FALSE`, `_TRUE`},
+	{`_IF _TRUE a b`, `a`},
+	{`_IF _FALSE a b`, `b`},
+
+	// Arithmetic
+	{`_SUCC _0`, `_1`},
+	{`_SUCC _1`, `_2`},
+	{`_SUCC _4`, `_5`},
+	{`_PRED _3`, `_2`},
+	{`_PRED _0`, `_0`},
+	{`_PLUS _2 _3`, `_5`},
+	{`_SUB _5 _2`, `_3`},
+	{`_SUB _2 _5`, `_0`},
+	{`_MULT _2 _3`, `_6`},
+	{`_POW _2 _3`, `_8`},
+	{`_MOD _7 _3`, `_1`},
+	{`_GCD _2 _4`, `_2`},
+	{`_DOUBLE _3`, `_6`},
+	{`_HALVE _5`, `_2`},
+	{`_DIV2 _5`, `_2`},
+	{`_MAX _2 _3`, `_3`},
+	{`_MIN _2 _3`, `_2`},
+	{`_MAXF _2 _3`, `_3`},
+	{`_MINF _2 _3`, `_2`},
+	{`_POPCOUNT _3`, `_2`},
+	{`_POWMOD _2 _1 _3`, `_2`},
+	{`_DECOMPOSE _5`, `_PAIR _2 _1`},
+	{`_LET a f`, `f a`},
+	{`_FACTORIAL _3`, `_6`}, // through the Y combinator
+	{`_FAC _3`, `_6`},
+
+	// Predicates
+	{`_ISZERO _0`, `_TRUE`},
+	{`_ISZERO _2`, `_FALSE`},
+	{`_LEQ _2 _3`, `_TRUE`},
+	{`_LEQ _3 _2`, `_FALSE`},
+	{`_LT _2 _2`, `_FALSE`},
+	{`_EQ _2 _2`, `_TRUE`},
+	{`_EQ _2 _3`, `_FALSE`},
+	{`_GT _3 _2`, `_TRUE`},
+	{`_GEQ _2 _3`, `_FALSE`},
+	{`_CMP _1 _2`, `_0`},
+	{`_CMP _2 _2`, `_1`},
+	{`_CMP _3 _2`, `_2`},
+	{`_ISODD _3`, `_TRUE`},
+	{`_ISEVEN _3`, `_FALSE`},
+	{`_B2N _TRUE`, `_1`},
+	{`_N2B _0`, `_FALSE`},
+
+	// Pairs and state
+	{`_FIRST (_PAIR a b)`, `a`},
+	{`_SECOND (_PAIR a b)`, `b`},
+	{`_ITERSTATE _3 (λp._PAIR (_SUCC (_FIRST p)) _0) (_PAIR _0 _0)`, `_3`},
+
+	// Lists
+	{`_HEAD (_CONS a _NIL)`, `a`},
+	{`_NULL _NIL`, `_TRUE`},
+	{`_NULL (_CONS a _NIL)`, `_FALSE`},
+	{`_LENGTH (_CONS a (_CONS b _NIL))`, `_2`},
+	{`_MAP f (_CONS a (_CONS b _NIL))`, `_CONS (f a) (_CONS (f b) _NIL)`},
+	{`_FOLDR f z (_CONS a (_CONS b _NIL))`, `f a (f b z)`},
+	{`_FOLDL f z (_CONS a (_CONS b _NIL))`, `f (f z a) b`},
+	{`_APPEND (_CONS a _NIL) (_CONS b _NIL)`, `_CONS a (_CONS b _NIL)`},
+	{`_REVERSE (_CONS a (_CONS b _NIL))`, `_CONS b (_CONS a _NIL)`},
+	{`_COUNT _ISZERO (_CONS _0 (_CONS _1 (_CONS _0 _NIL)))`, `_2`},
+	{`_RANGE _2`, `_CONS _0 (_CONS _1 _NIL)`},
+	{`_TIMES _2 f x`, `f (f x)`},
+
+	// Signed integers, compared as unnormalized pairs
+	{`_SINT _2`, `_PAIR _2 _0`},
+	{`_NEG _2`, `_PAIR _0 _2`},
+	{`_SINT_ADD (_SINT _2) (_NEG _3)`, `_PAIR _2 _3`},
+	{`_SINT_SUB (_SINT _2) (_NEG _3)`, `_PAIR _5 _0`},
+	{`_SINT_NEG (_PAIR _2 _1)`, `_PAIR _1 _2`},
+
+	// Self-interpreter
+	{`_EVAL (λa.λb.λc.b (λa.λb.λc.c (λx.λa.λb.λc.a x)) (λa.λb.λc.a y))`, `y`},
+}
+
+// VerifyConstants checks that the package constants behave as documented,
+// by reducing each of them applied to representative arguments, for
+// example K a b = a, I x = x or SUCC n = n+1 for small n. It returns one
+// error per property that does not hold, or nil if all of them do.
+func VerifyConstants() []error {
+	return verify(constantChecks)
+}
+
+func verify(checks []constantCheck) []error {
+	var errs []error
+	for _, c := range checks {
+		if err := c.verify(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (c constantCheck) verify() error {
+	got, err := c.normalize(c.expr)
+	if err != nil {
+		return err
+	}
+	want, err := c.normalize(c.want)
+	if err != nil {
+		return err
+	}
+	if !AlphaEquivalent(got, want) {
+		return fmt.Errorf("%s: got %s, want %s", c.expr, got, want)
+	}
+	return nil
+}
+
+func (c constantCheck) normalize(src string) (Term, error) {
+	t, err := Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src, err)
+	}
+	nf, ok := normalForm(t, verifyStepLimit)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", src, ErrNotNormalized)
+	}
+	return nf, nil
+}
@@ -0,0 +1,23 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestVerifyConstants(t *testing.T) {
+	for _, err := range VerifyConstants() {
+		t.Error(err)
+	}
+}
+
+func TestVerifyReportsFailures(t *testing.T) {
+	errs := verify([]constantCheck{
+		{`_K a b`, `a`},
+		{`_K a b`, `b`},
+		{`_OMEGA`, `x`},
+		{`(`, `x`},
+	})
+	if len(errs) != 3 {
+		t.Fatalf("verify returned %d errors, want 3: %v", len(errs), errs)
+	}
+}
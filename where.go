@@ -0,0 +1,177 @@
+package lambda
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Where clauses
+//
+// An expression may be followed by local definitions, which read after the
+// expression that uses them:
+//
+//	f (g _2) where { f = λn._PLUS n n; g = _SUCC }
+//
+// The definitions may refer to each other in any order. Without cycles
+// between them, the clause is the nested let (λf.(λg.E) G) F, definitions
+// before the ones using them. Definitions referring to themselves or to
+// each other in a cycle are bound together through a fixed point: the list
+// of their values is built by Y, each name standing for an element.
+
+// whereDef is a definition of a where clause
+type whereDef struct {
+	name  string
+	value Term
+}
+
+// atWhere reports whether the input continues with a where clause, the
+// word where followed by '{'
+func (p *Parser) atWhere() bool {
+	rest := p.input[p.pos:]
+	if !strings.HasPrefix(rest, "where") {
+		return false
+	}
+	rest = strings.TrimLeftFunc(rest[len("where"):], unicode.IsSpace)
+	return strings.HasPrefix(rest, "{")
+}
+
+// parseWhere parses an optional where clause after body and returns body
+// with the definitions bound
+func (p *Parser) parseWhere(body Term) (Term, error) {
+	p.skipWhitespace()
+	if !p.atWhere() {
+		return body, nil
+	}
+	p.pos += len("where")
+	p.skipWhitespace()
+	p.pos++ // '{'
+
+	var defs []whereDef
+	seen := make(map[string]bool)
+	for {
+		p.skipWhitespace()
+		if p.peek() == '}' {
+			p.pos++
+			break
+		}
+
+		start := p.pos
+		name := p.parseIdentifier()
+		if name == "" || p.isKeyword(name) {
+			return nil, fmt.Errorf("expected definition name at position %d", start)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s is already defined at position %d", name, start)
+		}
+		seen[name] = true
+		p.skipWhitespace()
+		if p.peek() != '=' {
+			return nil, fmt.Errorf("expected '=' after %s at position %d", name, p.pos)
+		}
+		p.pos++
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, whereDef{name, value})
+
+		p.skipWhitespace()
+		switch p.peek() {
+		case ';':
+			p.pos++
+		case '}':
+		default:
+			return nil, fmt.Errorf("expected ';' or '}' at position %d", p.pos)
+		}
+	}
+
+	return bindWhere(defs, body), nil
+}
+
+// bindWhere binds the definitions around body, as nested lets in
+// dependency order or through a fixed point if they are recursive.
+func bindWhere(defs []whereDef, body Term) Term {
+	index := make(map[string]int, len(defs))
+	for i, d := range defs {
+		index[d.name] = i
+	}
+
+	// Order the definitions so each comes after the ones it uses
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(defs))
+	var order []whereDef
+	recursive := false
+	var visit func(i int)
+	visit = func(i int) {
+		switch state[i] {
+		case visiting:
+			recursive = true
+			return
+		case done:
+			return
+		}
+		state[i] = visiting
+		for _, dep := range FreeVarNames(defs[i].value) {
+			if j, ok := index[dep]; ok {
+				visit(j)
+			}
+		}
+		state[i] = done
+		order = append(order, defs[i])
+	}
+	for i := range defs {
+		visit(i)
+	}
+
+	if !recursive {
+		for i := len(order) - 1; i >= 0; i-- {
+			body = Application{Func: Abstraction{Param: order[i].name, Body: body}, Arg: order[i].value}
+		}
+		return body
+	}
+
+	// r is the list of the values; each name stands for HEAD (TAIL^i r)
+	names := make(map[string]bool)
+	varNames(body, names)
+	for _, d := range defs {
+		names[d.name] = true
+		varNames(d.value, names)
+	}
+	r := freshVar("r", names)
+	subst := make(map[string]Term, len(defs))
+	for i, d := range defs {
+		var rest Term = Var{Name: r}
+		for j := 0; j < i; j++ {
+			rest = Application{Func: TAIL, Arg: rest}
+		}
+		subst[d.name] = Application{Func: HEAD, Arg: rest}
+	}
+
+	var values Term = NIL
+	for i := len(defs) - 1; i >= 0; i-- {
+		values = Application{
+			Func: Application{Func: CONS, Arg: SubstituteAll(defs[i].value, subst)},
+			Arg:  values,
+		}
+	}
+	fixed := Application{Func: Y, Arg: Abstraction{Param: r, Body: values}}
+	return Application{Func: Abstraction{Param: r, Body: SubstituteAll(body, subst)}, Arg: fixed}
+}
+
+// isWhereWord reports whether the identifier just parsed, starting at
+// start, is the where introducing a clause rather than a variable
+func (p *Parser) isWhereWord(name string, start int) bool {
+	if name != "where" {
+		return false
+	}
+	end := p.pos
+	p.pos = start
+	at := p.atWhere()
+	p.pos = end
+	return at
+}
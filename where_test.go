@@ -0,0 +1,71 @@
+package lambda
+
+import (
+	"testing"
+)
+
+func TestParseWhere(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"f _3 where { f = λn._PLUS n n }", 6},
+		{"f (g _2) where { f = λn._PLUS n n; g = _SUCC; }", 6},
+		// Definitions in any order, using each other
+		{"a where { a = _SUCC b; b = _PLUS c c; c = _2 }", 5},
+		// Definitions see the variables of the enclosing abstraction
+		{"(λx.f _1 where { f = _PLUS x }) _4", 5},
+		// Nested where clauses
+		{"f _1 where { f = _PLUS g where { g = _2 } }", 3},
+		// Self reference through a fixed point
+		{"fact _3 where { fact = λn._IF (_ISZERO n) _1 (_MULT n (fact (_PRED n))) }", 6},
+		// Mutual recursion
+		{"_B2N (even _3) where { even = λn._IF (_ISZERO n) _TRUE (odd (_PRED n)); odd = λn._IF (_ISZERO n) _FALSE (even (_PRED n)) }", 0},
+		{"_B2N (odd _3) where { even = λn._IF (_ISZERO n) _TRUE (odd (_PRED n)); odd = λn._IF (_ISZERO n) _FALSE (even (_PRED n)) }", 1},
+	}
+
+	for _, tt := range tests {
+		result, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got, ok := ToIntPure(result, 100000); !ok || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v, want %d", tt.input, got, ok, tt.want)
+		}
+	}
+
+	// Without a following '{', where is an ordinary variable
+	result, err := Parse("λwhere.f where")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if result.String() != "λwhere.f where" {
+		t.Errorf("Parse = %s", result)
+	}
+
+	for _, input := range []string{
+		"f where {",
+		"f where { f }",
+		"f where { f = _1 g = _2 }",
+		"f where { f = _1; f = _2 }",
+		"f where { = _1 }",
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", input)
+		}
+	}
+}
+
+func TestProgramWhere(t *testing.T) {
+	prog, err := ParseProgram(`
+		quad = double double where { double = λf.λn.f (f n) }; inc = _SUCC
+		quad inc _0 where { unused = _1; other = _2 }
+	`)
+	if err != nil {
+		t.Fatalf("ParseProgram error: %v", err)
+	}
+	if got, ok := ToIntPure(prog.Main, 10000); !ok || got != 4 {
+		t.Errorf("main = %d, %v, want 4", got, ok)
+	}
+}